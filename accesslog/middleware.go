@@ -0,0 +1,125 @@
+// Package accesslog provides a structured access log middleware built on gologger.
+package accesslog
+
+import (
+	"maps"
+	"math/rand/v2"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/mekramy/gohttp/requestid"
+	"github.com/mekramy/gohttp/session"
+	"github.com/mekramy/gologger"
+)
+
+// NewMiddleware creates a new access log middleware for Fiber framework.
+// Requests are logged at info level, slow requests and 4xx responses at warn level
+// and 5xx responses at error level. Errors returned by next handlers are passed to
+// the app error handler to resolve the final status code and are not returned, so
+// middlewares registered before access log see a nil error. Register it after requestid
+// and before middlewares that act on handler errors (e.g. session and limiter).
+func NewMiddleware(l gologger.Logger, options ...Options) fiber.Handler {
+	// Generate option
+	option := &Option{
+		sample: 1,
+		slow:   0,
+		redact: []string{"token", "access_token", "password", "secret", "api_key"},
+		next:   nil,
+	}
+	for _, opt := range options {
+		opt(option)
+	}
+
+	return func(c *fiber.Ctx) error {
+		// Skip
+		if l == nil || (option.next != nil && option.next(c)) {
+			return c.Next()
+		}
+
+		// Process request
+		start := time.Now()
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		latency := time.Since(start)
+		status := c.Response().StatusCode()
+		slow := option.slow > 0 && latency >= option.slow
+
+		// Sample regular requests
+		if status < 400 && !slow && option.sample < 1 && rand.Float64() >= option.sample {
+			return nil
+		}
+
+		// Log
+		params := make([]gologger.LogOptions, 0)
+		params = append(params, gologger.With("method", utils.CopyString(c.Method())))
+		params = append(params, gologger.With("path", utils.CopyString(c.Path())))
+		params = append(params, gologger.With("route", utils.CopyString(c.Route().Path)))
+		if query := redactQuery(c.Request().URI().QueryString(), option.redact); query != "" {
+			params = append(params, gologger.With("query", query))
+		}
+		params = append(params, gologger.With("status", status))
+		params = append(params, gologger.With("latency", latency.String()))
+		if size, ok := responseSize(c); ok {
+			params = append(params, gologger.With("bytes", size))
+		}
+		params = append(params, gologger.With("ip", utils.CopyString(c.IP())))
+		params = append(params, gologger.With("agent", utils.CopyString(c.Get(fiber.HeaderUserAgent))))
+		if id := requestid.Parse(c); id != "" {
+			params = append(params, gologger.With("request_id", id))
+		}
+		if s := session.Parse(c); s != nil {
+			params = append(params, gologger.With("session_id", s.Id()))
+		}
+
+		switch {
+		case status >= 500:
+			l.Error(params...)
+		case status >= 400 || slow:
+			l.Warn(params...)
+		default:
+			l.Info(params...)
+		}
+		return nil
+	}
+}
+
+// redactQuery masks the values of sensitive query parameters.
+func redactQuery(raw []byte, names []string) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	values, _ := url.ParseQuery(string(raw))
+	pairs := make([]string, 0, len(values))
+	for _, k := range slices.Sorted(maps.Keys(values)) {
+		sensitive := slices.Contains(names, strings.ToLower(k))
+		for _, v := range values[k] {
+			if sensitive {
+				v = "[REDACTED]"
+			} else {
+				v = url.QueryEscape(v)
+			}
+			pairs = append(pairs, url.QueryEscape(k)+"="+v)
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// responseSize returns the response body size without reading body streams.
+// Size of streams is taken from Content-Length header and is unknown for chunked streams.
+func responseSize(c *fiber.Ctx) (int, bool) {
+	if !c.Response().IsBodyStream() {
+		return len(c.Response().Body()), true
+	}
+	if size := c.Response().Header.ContentLength(); size >= 0 {
+		return size, true
+	}
+	return 0, false
+}
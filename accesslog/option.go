@@ -0,0 +1,56 @@
+package accesslog
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Options defines a function type for configuring Access Log Option.
+type Options func(*Option)
+
+// Option holds the configuration options for Access Log middleware.
+type Option struct {
+	sample float64               // Sampling rate of regular requests, between 0 and 1
+	slow   time.Duration         // Latency threshold that raises log level to warn
+	redact []string              // Query parameters to redact
+	next   func(*fiber.Ctx) bool // Function to skip logging for certain requests
+}
+
+// WithSampling sets the sampling rate of regular requests between 0 and 1.
+// Failed and slow requests are always logged.
+func WithSampling(rate float64) Options {
+	return func(c *Option) {
+		if rate >= 0 && rate <= 1 {
+			c.sample = rate
+		}
+	}
+}
+
+// WithSlowThreshold sets the latency threshold that raises log level to warn.
+func WithSlowThreshold(threshold time.Duration) Options {
+	return func(c *Option) {
+		if threshold > 0 {
+			c.slow = threshold
+		}
+	}
+}
+
+// WithRedactQuery adds query parameters to redact (case-insensitive).
+func WithRedactQuery(names ...string) Options {
+	return func(c *Option) {
+		for _, name := range names {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				c.redact = append(c.redact, name)
+			}
+		}
+	}
+}
+
+// WithNext sets a custom function to skip logging for certain requests.
+func WithNext(handler func(*fiber.Ctx) bool) Options {
+	return func(c *Option) {
+		c.next = handler
+	}
+}
@@ -0,0 +1,108 @@
+package gohttp
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/valyala/fasthttp"
+)
+
+// Classifier maps a generic error to HttpError.
+// It returns false if the error is not recognized.
+type Classifier func(err error) (HttpError, bool)
+
+// ClassifyIs creates a classifier that maps errors matching target (errors.Is) to the status code.
+// Empty message defaults to status text.
+func ClassifyIs(target error, status int, message string) Classifier {
+	return func(err error) (HttpError, bool) {
+		if errors.Is(err, target) {
+			return classified(err, status, message), true
+		}
+		return HttpError{}, false
+	}
+}
+
+// ClassifyAs creates a classifier that maps errors of type T (errors.As) to the status code.
+// Empty message defaults to status text.
+func ClassifyAs[T error](status int, message string) Classifier {
+	return func(err error) (HttpError, bool) {
+		var target T
+		if errors.As(err, &target) {
+			return classified(err, status, message), true
+		}
+		return HttpError{}, false
+	}
+}
+
+// classifiers is the global classifier registry.
+var classifiers = struct {
+	mutex sync.RWMutex
+	app   []Classifier
+	std   []Classifier
+}{
+	std: []Classifier{
+		ClassifyIs(context.DeadlineExceeded, fiber.StatusRequestTimeout, ""),
+		ClassifyAs[*json.SyntaxError](fiber.StatusBadRequest, ""),
+		ClassifyAs[*json.UnmarshalTypeError](fiber.StatusBadRequest, ""),
+		ClassifyAs[*xml.SyntaxError](fiber.StatusBadRequest, ""),
+		ClassifyIs(fasthttp.ErrBodyTooLarge, fiber.StatusRequestEntityTooLarge, ""),
+		ClassifyIs(os.ErrNotExist, fiber.StatusNotFound, ""),
+		ClassifyIs(sql.ErrNoRows, fiber.StatusNotFound, ""),
+	},
+}
+
+// RegisterClassifier registers an application classifier used by error handler
+// to map errors that are not HttpError or fiber.Error.
+// Application classifiers are checked in registration order before the built-in mappings:
+// context.DeadlineExceeded (408), JSON and XML syntax errors (400), fasthttp.ErrBodyTooLarge (413),
+// os.ErrNotExist (404) and sql.ErrNoRows (404).
+func RegisterClassifier(classifier Classifier) {
+	if classifier == nil {
+		return
+	}
+
+	classifiers.mutex.Lock()
+	defer classifiers.mutex.Unlock()
+	classifiers.app = append(classifiers.app, classifier)
+}
+
+// Classify maps the error to HttpError using registered classifiers.
+// It returns false if no classifier recognizes the error.
+func Classify(err error) (HttpError, bool) {
+	classifiers.mutex.RLock()
+	defer classifiers.mutex.RUnlock()
+
+	for _, classifier := range classifiers.app {
+		if he, ok := classifier(err); ok {
+			return he, true
+		}
+	}
+
+	for _, classifier := range classifiers.std {
+		if he, ok := classifier(err); ok {
+			return he, true
+		}
+	}
+
+	return HttpError{}, false
+}
+
+// classified creates the HttpError of classified error.
+func classified(err error, status int, message string) HttpError {
+	if message == "" {
+		message = utils.StatusMessage(status)
+	}
+
+	return HttpError{
+		Status:  status,
+		Message: message,
+		Cause:   err,
+	}
+}
@@ -0,0 +1,84 @@
+package gohttp
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrorCode represents the registered definition of a machine-readable error code.
+type ErrorCode struct {
+	Status  int    // Default HTTP status code.
+	Message string // Message template, "{name}" placeholders are replaced by params.
+}
+
+// codes is the global error code registry.
+var codes = struct {
+	mutex sync.RWMutex
+	data  map[string]ErrorCode
+}{data: make(map[string]ErrorCode)}
+
+// RegisterCode registers an error code with its default status code and message template.
+// Message template can contain "{name}" placeholders which are replaced by error params.
+// Registering an existing code overrides it.
+func RegisterCode(code string, status int, message string) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return
+	}
+
+	codes.mutex.Lock()
+	defer codes.mutex.Unlock()
+	codes.data[code] = ErrorCode{
+		Status:  status,
+		Message: message,
+	}
+}
+
+// LookupCode returns the registered definition of an error code.
+func LookupCode(code string) (ErrorCode, bool) {
+	codes.mutex.RLock()
+	defer codes.mutex.RUnlock()
+
+	def, ok := codes.data[code]
+	return def, ok
+}
+
+// NewCodedError creates a new HttpError from the registered error code and params.
+// Status code and message are resolved from the code registry.
+// Unregistered codes produce 500 status code and the code as message.
+// It also captures the file and line number where the error occurred and the call stack if CaptureStack is enabled.
+func NewCodedError(code string, params map[string]any) error {
+	def, ok := LookupCode(code)
+	if !ok {
+		def = ErrorCode{
+			Status:  500,
+			Message: code,
+		}
+	}
+
+	file, line, _ := realCaller()
+	return HttpError{
+		Line:    line,
+		File:    file,
+		Body:    nil,
+		Status:  def.Status,
+		Message: formatMessage(def.Message, params),
+		Code:    code,
+		Params:  params,
+		Stack:   callerStack(),
+	}
+}
+
+// formatMessage replaces "{name}" placeholders of the message template with params.
+func formatMessage(tmpl string, params map[string]any) string {
+	if len(params) == 0 || !strings.Contains(tmpl, "{") {
+		return tmpl
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+	for k, v := range params {
+		pairs = append(pairs, "{"+k+"}", fmt.Sprint(v))
+	}
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
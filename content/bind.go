@@ -0,0 +1,196 @@
+package content
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/mekramy/gohttp"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// bindTypes are the request body media types supported by Bind.
+var bindTypes = []string{
+	fiber.MIMEApplicationJSON,
+	fiber.MIMEApplicationXML,
+	fiber.MIMETextXML,
+	fiber.MIMEApplicationForm,
+	fiber.MIMEMultipartForm,
+	MIMEApplicationMsgPack,
+}
+
+// Bind decodes the request into a new T struct and validates it.
+// The body decoder is selected from the request Content-Type: JSON ("+json" types) using the app JSONDecoder,
+// XML ("+xml" types), form and multipart form using BodyParser ("form" tags) and MessagePack.
+// Then route params ("params" tag), query ("query" tag) and headers ("reqHeader" tag) are merged into the struct.
+// Only fields with these tags are populated from route params, query and headers.
+// Finally the struct is validated using Validate.
+//
+// It returns a 415 Unsupported Media Type gohttp.HttpError for unsupported non-empty bodies,
+// a 400 Bad Request gohttp.HttpError for malformed input and a 422 gohttp validation error
+// with field failures if validation fails. Errors are reported at the caller file and line.
+func Bind[T any](c *fiber.Ctx) (T, error) {
+	var v T
+	if err := decodeBody(c, &v); err != nil {
+		return v, withCaller(err)
+	}
+
+	if err := bindSources(c, reflect.ValueOf(&v).Elem()); err != nil {
+		return v, withCaller(err)
+	}
+
+	if err := Validate(c, &v); err != nil {
+		return v, withCaller(err)
+	}
+	return v, nil
+}
+
+// decodeBody decodes the request body based on Content-Type.
+func decodeBody(c *fiber.Ctx, v any) error {
+	body := c.Body()
+	if len(body) == 0 {
+		return nil
+	}
+
+	m, ok := parseMediaType(c.Get(fiber.HeaderContentType))
+	if !ok {
+		return unsupported(c, strings.Join(bindTypes, ", "))
+	}
+
+	var err error
+	switch {
+	case m.sub == "json" || m.suffix() == "json":
+		err = c.App().Config().JSONDecoder(body, v)
+	case m.sub == "xml" || m.suffix() == "xml":
+		err = xml.Unmarshal(body, v)
+	case m.String() == fiber.MIMEApplicationForm, m.String() == fiber.MIMEMultipartForm:
+		err = c.BodyParser(v)
+	case strings.TrimPrefix(strings.TrimPrefix(m.sub, "vnd."), "x-") == "msgpack":
+		err = msgpack.Unmarshal(body, v)
+	default:
+		return unsupported(c, strings.Join(bindTypes, ", "))
+	}
+
+	if err != nil {
+		return gohttp.BadRequest("").WithCause(err)
+	}
+	return nil
+}
+
+// bindSources populates the tagged fields of struct from route params, query and headers.
+func bindSources(c *fiber.Ctx, v reflect.Value) error {
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && value.Kind() == reflect.Struct {
+			if err := bindSources(c, value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var values []string
+		if name := tagName(field, "params"); name != "" && c.Params(name) != "" {
+			values = []string{utils.CopyString(c.Params(name))}
+		}
+		if name := tagName(field, "query"); name != "" {
+			if args := c.Context().QueryArgs().PeekMulti(name); len(args) > 0 {
+				values = nil
+				for _, arg := range args {
+					values = append(values, string(arg))
+				}
+			}
+		}
+		if name := tagName(field, "reqHeader"); name != "" && c.Get(name) != "" {
+			values = []string{utils.CopyString(c.Get(name))}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := setValue(value, values); err != nil {
+			return gohttp.BadRequest(fmt.Sprintf("invalid %s value", field.Name)).WithCause(err)
+		}
+	}
+
+	return nil
+}
+
+// setValue converts and sets the string values into the v.
+// Slices receive all values and other kinds the last value.
+func setValue(v reflect.Value, values []string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		ptr := reflect.New(v.Type().Elem())
+		if err := setValue(ptr.Elem(), values); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	case reflect.Slice:
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setValue(slice.Index(i), []string{value}); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+
+	value := values[len(values)-1]
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// tagName returns the name of struct tag without options.
+// It returns empty string for missing or "-" tags.
+func tagName(field reflect.StructField, tag string) string {
+	name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
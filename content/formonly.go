@@ -6,15 +6,8 @@ import (
 
 // FormOnly is a middleware that ensures the request's Content-Type is either "multipart/form-data" or
 // "application/x-www-form-urlencoded". If the Content-Type is neither of these, it will execute the
-// optional onFail handler if provided, or return a 406 Not Acceptable status by default.
+// optional onFail handler if provided, or return a 415 Unsupported Media Type error by default.
+// It is a preset of OnlyOr.
 func FormOnly(onFail ...fiber.Handler) fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		if !isValidContent(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm, fiber.MIMEApplicationForm) {
-			if len(onFail) > 0 && onFail[0] != nil {
-				return onFail[0](c)
-			}
-			return c.SendStatus(fiber.StatusNotAcceptable)
-		}
-		return c.Next()
-	}
+	return OnlyOr(failHandler(onFail), fiber.MIMEMultipartForm, fiber.MIMEApplicationForm)
 }
@@ -4,17 +4,15 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-// JsonOnly is a middleware that ensures the request's Content-Type is "application/json".
-// If the Content-Type is not "application/json", it will execute the optional onFail handler
-// if provided, or return a 406 Not Acceptable status by default.
+// patchTypes are JSON patch document media types rejected by JsonOnly.
+var patchTypes = []string{"application/json-patch+json", "application/merge-patch+json"}
+
+// JsonOnly is a middleware that ensures the request's Content-Type is "application/json" or a "+json"
+// structured syntax suffix type such as "application/vnd.api+json". JSON Patch (RFC 6902) and JSON Merge
+// Patch (RFC 7396) documents are rejected since they describe changes rather than the resource itself,
+// use Only with their media types to accept them. If the Content-Type doesn't match, it will execute
+// the optional onFail handler if provided, or return a 415 Unsupported Media Type error by default.
+// It is a preset of OnlyOr.
 func JsonOnly(onFail ...fiber.Handler) fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		if !isValidContent(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
-			if len(onFail) > 0 && onFail[0] != nil {
-				return onFail[0](c)
-			}
-			return c.SendStatus(fiber.StatusNotAcceptable)
-		}
-		return c.Next()
-	}
+	return only(failHandler(onFail), patchTypes, fiber.MIMEApplicationJSON)
 }
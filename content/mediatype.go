@@ -0,0 +1,183 @@
+package content
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// mediaType represents a parsed media type.
+type mediaType struct {
+	typ     string            // Top-level type, e.g. "application".
+	sub     string            // Subtype, e.g. "vnd.api+json".
+	params  map[string]string // Parameters with lower-cased names.
+	quality float64           // Quality value of Accept header, 1 by default.
+}
+
+// parseMediaType parses a media type with optional parameters.
+// Type, subtype and parameter names are lower-cased.
+func parseMediaType(v string) (mediaType, bool) {
+	full, params, err := mime.ParseMediaType(strings.TrimSpace(v))
+	if err != nil {
+		return mediaType{}, false
+	}
+
+	typ, sub, ok := strings.Cut(full, "/")
+	if !ok || typ == "" || sub == "" {
+		return mediaType{}, false
+	}
+
+	return mediaType{typ: typ, sub: sub, params: params, quality: 1}, true
+}
+
+// String returns the media type without parameters.
+func (m mediaType) String() string {
+	return m.typ + "/" + m.sub
+}
+
+// suffix returns the structured syntax suffix of subtype (e.g. "json" for "vnd.api+json").
+func (m mediaType) suffix() string {
+	if i := strings.LastIndexByte(m.sub, '+'); i >= 0 {
+		return m.sub[i+1:]
+	}
+	return ""
+}
+
+// matches checks if the concrete media type v matches the m pattern.
+// Pattern supports "*/*" and "type/*" wildcards, "type/*+suffix" suffix wildcards
+// and structured syntax suffixes ("application/json" matches "application/vnd.api+json").
+// All parameters of the pattern must be present in v with equal (case-insensitive) values.
+func (m mediaType) matches(v mediaType) bool {
+	return m.matchesType(v, true) && m.matchesParams(v)
+}
+
+// accepts checks if the m Accept header range accepts the concrete media type v.
+// Unlike matches, structured syntax suffixes must be requested explicitly using "type/*+suffix" ranges.
+func (m mediaType) accepts(v mediaType) bool {
+	return m.matchesType(v, false) && m.matchesParams(v)
+}
+
+// matchesParams checks if all parameters of the m pattern are present in v with equal (case-insensitive) values.
+func (m mediaType) matchesParams(v mediaType) bool {
+	for k, p := range m.params {
+		if k == "q" {
+			continue
+		}
+		if !strings.EqualFold(v.params[k], p) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesType checks if the concrete media type v matches the m pattern ignoring parameters.
+func (m mediaType) matchesType(v mediaType, suffix bool) bool {
+	if m.typ == "*" {
+		return true
+	}
+	if m.typ != v.typ {
+		return false
+	}
+
+	switch {
+	case m.sub == "*", m.sub == v.sub:
+		return true
+	case strings.HasPrefix(m.sub, "*+"):
+		return v.suffix() == m.sub[2:]
+	default:
+		return suffix && v.suffix() != "" && v.suffix() == m.sub
+	}
+}
+
+// specificity returns the precedence of media type pattern.
+// Concrete types with parameters are more specific than concrete types,
+// suffix wildcards, subtype wildcards and "*/*" respectively.
+func (m mediaType) specificity() int {
+	switch {
+	case m.typ == "*":
+		return 0
+	case m.sub == "*":
+		return 1
+	case strings.HasPrefix(m.sub, "*+"):
+		return 2
+	}
+
+	res := 3
+	for k := range m.params {
+		if k != "q" {
+			res++
+		}
+	}
+	return res
+}
+
+// parseMediaTypes parses the valid media types of list.
+func parseMediaTypes(types ...string) []mediaType {
+	res := make([]mediaType, 0, len(types))
+	for _, t := range types {
+		if m, ok := parseMediaType(t); ok {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+// parseAccept parses the media ranges of Accept header with their quality values.
+// Ranges with invalid quality values are ignored and a single "*" is treated as "*/*".
+func parseAccept(accept string) []mediaType {
+	res := make([]mediaType, 0)
+	for _, part := range strings.Split(accept, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if part == "*" || strings.HasPrefix(part, "*;") {
+			part = "*/*" + part[1:]
+		}
+
+		m, ok := parseMediaType(part)
+		if !ok {
+			continue
+		}
+
+		if q, ok := m.params["q"]; ok {
+			quality, err := strconv.ParseFloat(q, 64)
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+			m.quality = quality
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+// negotiate selects the offer with the highest quality value accepted by the Accept header.
+// The quality of each offer is taken from the most specific matching range. Offers order
+// is used as the server preference on ties. If the Accept header is empty, the first offer is selected.
+// It returns the index of selected offer or -1 if no offer is acceptable.
+func negotiate(accept string, offers ...mediaType) int {
+	if len(offers) == 0 {
+		return -1
+	}
+	if strings.TrimSpace(accept) == "" {
+		return 0
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQuality := -1, 0.0
+	for i, offer := range offers {
+		quality, specificity := 0.0, -1
+		for _, r := range ranges {
+			if r.accepts(offer) && r.specificity() > specificity {
+				quality, specificity = r.quality, r.specificity()
+			}
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = i, quality
+		}
+	}
+
+	return best
+}
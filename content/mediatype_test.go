@@ -0,0 +1,132 @@
+package content
+
+import (
+	"testing"
+)
+
+func TestMediaTypeMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		content string
+		match   bool
+	}{
+		{"application/json", "application/json", true},
+		{"application/json", "Application/JSON", true},
+		{"application/json", "application/json; charset=utf-8", true},
+		{"application/json", "application/vnd.api+json", true},
+		{"application/json", "application/problem+json", true},
+		{"application/json", "application/jsonp", false},
+		{"application/json", "text/json", false},
+		{"application/json", "application/xml", false},
+		{"application/xml", "application/atom+xml", true},
+		{"application/*", "application/pdf", true},
+		{"application/*", "text/plain", false},
+		{"*/*", "image/png", true},
+		{"application/*+json", "application/vnd.api+json", true},
+		{"application/*+json", "application/json", false},
+		{"application/*+json", "application/vnd.api+xml", false},
+		{"text/plain; charset=utf-8", "text/plain; charset=UTF-8", true},
+		{"text/plain; charset=utf-8", "text/plain", false},
+		{"text/plain; charset=utf-8", "text/plain; charset=latin1", false},
+	}
+
+	for _, test := range tests {
+		pattern, ok := parseMediaType(test.pattern)
+		if !ok {
+			t.Fatalf("invalid pattern %q", test.pattern)
+		}
+		content, ok := parseMediaType(test.content)
+		if !ok {
+			t.Fatalf("invalid content %q", test.content)
+		}
+
+		if res := pattern.matches(content); res != test.match {
+			t.Errorf("%q matches %q: got %v, expected %v", test.pattern, test.content, res, test.match)
+		}
+	}
+}
+
+func TestJsonOnlyContent(t *testing.T) {
+	valids := parseMediaTypes("application/json")
+	excludes := parseMediaTypes(patchTypes...)
+	tests := []struct {
+		content string
+		valid   bool
+	}{
+		{"application/json", true},
+		{"application/json; charset=utf-8", true},
+		{"application/vnd.api+json", true},
+		{"application/json-patch+json", false},
+		{"application/merge-patch+json; charset=utf-8", false},
+		{"application/*", false},
+		{"text/plain", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		res := isValidContent(test.content, valids...) && !isExcludedContent(test.content, excludes...)
+		if res != test.valid {
+			t.Errorf("%q: got %v, expected %v", test.content, res, test.valid)
+		}
+	}
+}
+
+func TestParseAccept(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected []string
+		quality  []float64
+	}{
+		{"", nil, nil},
+		{"application/json", []string{"application/json"}, []float64{1}},
+		{"text/html;q=0.8, application/json", []string{"text/html", "application/json"}, []float64{0.8, 1}},
+		{"*", []string{"*/*"}, []float64{1}},
+		{"*;q=0.1", []string{"*/*"}, []float64{0.1}},
+		{"Application/JSON ; Q=0.5", []string{"application/json"}, []float64{0.5}},
+		{"text/html;q=2, text/plain;q=abc, application/xml;q=0", []string{"application/xml"}, []float64{0}},
+		{"invalid, , application/json", []string{"application/json"}, []float64{1}},
+	}
+
+	for _, test := range tests {
+		ranges := parseAccept(test.accept)
+		if len(ranges) != len(test.expected) {
+			t.Errorf("%q: got %d ranges, expected %d", test.accept, len(ranges), len(test.expected))
+			continue
+		}
+		for i, r := range ranges {
+			if r.String() != test.expected[i] || r.quality != test.quality[i] {
+				t.Errorf("%q: got %s;q=%v, expected %s;q=%v", test.accept, r, r.quality, test.expected[i], test.quality[i])
+			}
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	offers := parseMediaTypes("application/json", "application/xml", "application/vnd.api+json")
+	tests := []struct {
+		accept   string
+		expected int
+	}{
+		{"", 0},
+		{"*/*", 0},
+		{"application/xml", 1},
+		{"application/xml, application/json", 0},
+		{"application/json;q=0.5, application/xml", 1},
+		{"application/*;q=0.5, application/xml;q=0.9", 1},
+		{"application/vnd.api+json", 2},
+		{"application/*+json", 2},
+		{"*/*;q=0.1, application/json;q=0", 1},
+		{"application/json;q=0, application/xml;q=0, application/vnd.api+json;q=0", -1},
+		{"text/html", -1},
+	}
+
+	for _, test := range tests {
+		if res := negotiate(test.accept, offers...); res != test.expected {
+			t.Errorf("%q: got %d, expected %d", test.accept, res, test.expected)
+		}
+	}
+
+	if res := negotiate("*/*"); res != -1 {
+		t.Errorf("no offers: got %d, expected -1", res)
+	}
+}
@@ -0,0 +1,53 @@
+package content
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mekramy/gohttp"
+)
+
+// MIMEApplicationMsgPack is the MessagePack media type.
+const MIMEApplicationMsgPack = "application/vnd.msgpack"
+
+// Negotiate is a middleware that selects the response media type from the provided offers
+// based on the request Accept header. Accept ranges are parsed with their quality values,
+// "*/*", "type/*" and "type/*+suffix" wildcards, and the most specific matching range decides
+// the quality of each offer. Offers order is used as the server preference on ties and the
+// first offer is selected when the Accept header is missing.
+// The selected media type is stored in the context and can be read using Negotiated.
+// If no offer is acceptable, it returns a 406 Not Acceptable gohttp.HttpError.
+func Negotiate(offers ...string) fiber.Handler {
+	return NegotiateOr(nil, offers...)
+}
+
+// NegotiateOr is like Negotiate but executes the onFail handler if provided when no offer is acceptable.
+func NegotiateOr(onFail fiber.Handler, offers ...string) fiber.Handler {
+	valids := make([]string, 0, len(offers))
+	parsed := make([]mediaType, 0, len(offers))
+	for _, offer := range offers {
+		if m, ok := parseMediaType(offer); ok {
+			valids = append(valids, offer)
+			parsed = append(parsed, m)
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Vary(fiber.HeaderAccept)
+		i := negotiate(c.Get(fiber.HeaderAccept), parsed...)
+		if i < 0 {
+			if onFail != nil {
+				return onFail(c)
+			}
+			return withoutCaller(gohttp.NotAcceptable(""))
+		}
+
+		c.Locals("NEGOTIATED_TYPE", valids[i])
+		return c.Next()
+	}
+}
+
+// Negotiated returns the media type selected by Negotiate middleware.
+// It returns empty string if negotiate middleware is not registered.
+func Negotiated(c *fiber.Ctx) string {
+	t, _ := c.Locals("NEGOTIATED_TYPE").(string)
+	return t
+}
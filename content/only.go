@@ -0,0 +1,62 @@
+package content
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mekramy/gohttp"
+)
+
+// Only is a middleware that ensures the request's Content-Type matches one of the provided media types.
+// Media types are parsed with their parameters and support "*/*" and "type/*" wildcards,
+// "type/*+suffix" suffix wildcards and structured syntax suffixes, e.g. "application/json"
+// accepts "application/vnd.api+json". Parameters of the provided media types (e.g. charset) must
+// be present in the request Content-Type. If the Content-Type doesn't match, it returns a
+// 415 Unsupported Media Type gohttp.HttpError advertising the accepted types in the Accept header,
+// and in the Accept-Post or Accept-Patch header for POST and PATCH requests.
+func Only(types ...string) fiber.Handler {
+	return OnlyOr(nil, types...)
+}
+
+// OnlyOr is like Only but executes the onFail handler if provided when the Content-Type doesn't match.
+func OnlyOr(onFail fiber.Handler, types ...string) fiber.Handler {
+	return only(onFail, nil, types...)
+}
+
+// only creates the Only middleware rejecting the excludes media types even if they match the types.
+func only(onFail fiber.Handler, excludes []string, types ...string) fiber.Handler {
+	valids := parseMediaTypes(types...)
+	excluded := parseMediaTypes(excludes...)
+	accepted := strings.Join(types, ", ")
+	return func(c *fiber.Ctx) error {
+		content := c.Get(fiber.HeaderContentType)
+		if !isValidContent(content, valids...) || isExcludedContent(content, excluded...) {
+			if onFail != nil {
+				return onFail(c)
+			}
+			return unsupported(c, accepted)
+		}
+		return c.Next()
+	}
+}
+
+// unsupported creates the 415 Unsupported Media Type error advertising the accepted types.
+// Error has no file and line since it is not created by application code.
+func unsupported(c *fiber.Ctx, accepted string) error {
+	err := withoutCaller(gohttp.UnsupportedMediaType("")).WithHeader(fiber.HeaderAccept, accepted)
+	switch c.Method() {
+	case fiber.MethodPost:
+		err = err.WithHeader("Accept-Post", accepted)
+	case fiber.MethodPatch:
+		err = err.WithHeader("Accept-Patch", accepted)
+	}
+	return err
+}
+
+// failHandler returns the optional onFail handler of presets.
+func failHandler(onFail []fiber.Handler) fiber.Handler {
+	if len(onFail) > 0 {
+		return onFail[0]
+	}
+	return nil
+}
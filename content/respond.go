@@ -0,0 +1,63 @@
+package content
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mekramy/gohttp"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// encoders are the response encoders supported by Respond in the order of preference.
+var encoders = []string{
+	fiber.MIMEApplicationJSON,
+	fiber.MIMEApplicationXML,
+	fiber.MIMETextXML,
+	MIMEApplicationMsgPack,
+	"application/msgpack",
+	"application/x-msgpack",
+}
+
+// Respond encodes v using the encoder of the media type selected by Negotiate middleware and sends it.
+// JSON ("application/json" and "+json" types), XML ("application/xml", "text/xml" and "+xml" types) and
+// MessagePack ("application/vnd.msgpack", "application/msgpack" and "application/x-msgpack") are supported.
+// JSON and XML are encoded using the app JSONEncoder and XMLEncoder.
+// If Negotiate middleware is not registered, the encoder is negotiated from the request Accept header.
+// If no encoder is acceptable, it returns a 406 Not Acceptable gohttp.HttpError.
+// Set the response status before calling Respond, e.g. Respond(c.Status(201), v).
+func Respond(c *fiber.Ctx, v any) error {
+	t := Negotiated(c)
+	if t == "" {
+		c.Vary(fiber.HeaderAccept)
+		i := negotiate(c.Get(fiber.HeaderAccept), parseMediaTypes(encoders...)...)
+		if i < 0 {
+			return withCaller(gohttp.NotAcceptable(""))
+		}
+		t = encoders[i]
+	}
+
+	m, ok := parseMediaType(t)
+	if !ok {
+		return fmt.Errorf("content: invalid media type %q", t)
+	}
+
+	var encoded []byte
+	var err error
+	switch {
+	case m.sub == "json" || m.suffix() == "json":
+		encoded, err = c.App().Config().JSONEncoder(v)
+	case m.sub == "xml" || m.suffix() == "xml":
+		encoded, err = c.App().Config().XMLEncoder(v)
+	case strings.TrimPrefix(strings.TrimPrefix(m.sub, "vnd."), "x-") == "msgpack":
+		encoded, err = msgpack.Marshal(v)
+	default:
+		return fmt.Errorf("content: no encoder for %q media type", t)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, t)
+	return c.Send(encoded)
+}
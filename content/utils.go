@@ -1,16 +1,64 @@
 package content
 
 import (
+	"runtime"
 	"strings"
+
+	"github.com/mekramy/gohttp"
 )
 
-func isValidContent(c string, valids ...string) bool {
-	c = strings.ToLower(strings.TrimSpace(c))
+// isValidContent checks if the c content type matches one of the valids media type patterns.
+func isValidContent(c string, valids ...mediaType) bool {
+	content, ok := parseMediaType(c)
+	if !ok || strings.Contains(content.String(), "*") {
+		return false
+	}
+
 	for _, v := range valids {
-		if strings.HasPrefix(c, strings.ToLower(v)) {
+		if v.matches(content) {
 			return true
 		}
 	}
 
 	return false
 }
+
+// isExcludedContent checks if the c content type is exactly one of the excludes media types.
+func isExcludedContent(c string, excludes ...mediaType) bool {
+	content, ok := parseMediaType(c)
+	if !ok {
+		return false
+	}
+
+	for _, e := range excludes {
+		if e.matchesType(content, false) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withCaller sets the file and line of gohttp.HttpError to the caller of the function calling withCaller,
+// so errors created by library helpers are logged at the application call site.
+// Other errors are returned unchanged.
+func withCaller(err error) error {
+	he, ok := err.(gohttp.HttpError)
+	if !ok {
+		return err
+	}
+
+	if _, f, l, ok := runtime.Caller(2); ok {
+		he.File = f
+		he.Line = l
+	}
+	return he
+}
+
+// withoutCaller clears the file and line of error created by middlewares,
+// which have no meaningful application call site.
+func withoutCaller(err gohttp.HttpError) gohttp.HttpError {
+	err.File = ""
+	err.Line = 0
+	return err
+}
@@ -0,0 +1,234 @@
+package content
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mekramy/gohttp"
+)
+
+// patterns caches the compiled regex rules.
+var patterns sync.Map
+
+// Validate validates the struct v (or pointer to struct) using the "validate" struct tag rules.
+// Rules are separated by comma:
+//
+//   - required: value must not be zero (empty string, nil, empty slice or map).
+//   - omitempty: other rules are skipped for zero values.
+//   - min=n, max=n: minimum and maximum of numbers, characters of strings or items of slices and maps.
+//   - email: value must be a valid email address.
+//   - oneof=a b c: value must be one of the space separated values.
+//   - regex=pattern: value must match the pattern. It must be the last rule since pattern may contain comma.
+//
+// Rules are applied to zero values unless omitempty is set, e.g. min=1 rejects 0 and empty lists.
+// Nil pointers are only checked by required. Nested structs, pointers to struct and
+// slices of structs are validated recursively. Field paths use the json (or form, xml, query, params,
+// reqHeader) tag name, e.g. "items[2].price".
+// It returns a 422 gohttp validation error with field failures and the caller file and line if validation fails.
+func Validate(c *fiber.Ctx, v any) error {
+	fields := make([]gohttp.FieldError, 0)
+	if err := validateValue(reflect.ValueOf(v), nil, &fields); err != nil {
+		return err
+	}
+
+	if len(fields) > 0 {
+		return withCaller(gohttp.NewValidationError(c, fields...))
+	}
+	return nil
+}
+
+// validateValue validates the nested structs of value.
+func validateValue(v reflect.Value, path []any, fields *[]gohttp.FieldError) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return validateStruct(v, path, fields)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateValue(v.Index(i), append(path, i), fields); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateStruct validates the fields of struct.
+func validateStruct(v reflect.Value, path []any, fields *[]gohttp.FieldError) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := path
+		if !field.Anonymous {
+			fieldPath = append(path[:len(path):len(path)], fieldName(field))
+		}
+
+		if tag := field.Tag.Get("validate"); tag != "" && tag != "-" {
+			message, err := validateField(value, tag)
+			if err != nil {
+				return fmt.Errorf("content: invalid validate tag of %s.%s: %w", t.Name(), field.Name, err)
+			}
+			if message != "" {
+				*fields = append(*fields, gohttp.FieldError{
+					Field:   gohttp.FieldPath(fieldPath...),
+					Message: message,
+				})
+				continue
+			}
+		}
+
+		if err := validateValue(value, fieldPath, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateField checks the value against the tag rules.
+// It returns the failure message of the first failed rule.
+func validateField(v reflect.Value, tag string) (string, error) {
+	rules := make([]string, 0)
+	for tag = strings.TrimSpace(tag); tag != ""; tag = strings.TrimSpace(tag) {
+		if strings.HasPrefix(tag, "regex=") {
+			rules = append(rules, tag)
+			break
+		}
+
+		var rule string
+		rule, tag, _ = strings.Cut(tag, ",")
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+
+	if v.IsZero() {
+		for _, rule := range rules {
+			if rule == "required" {
+				return "is required", nil
+			}
+		}
+		for _, rule := range rules {
+			if rule == "omitempty" {
+				return "", nil
+			}
+		}
+	}
+
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required", "omitempty":
+		case "min", "max":
+			limit, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				return "", err
+			}
+
+			size, unit, ok := measure(v)
+			if !ok {
+				return "", fmt.Errorf("%s rule is not supported for %s", name, v.Type())
+			}
+			if name == "min" && size < limit {
+				return fmt.Sprintf("must be at least %s%s", param, unit), nil
+			}
+			if name == "max" && size > limit {
+				return fmt.Sprintf("must be at most %s%s", param, unit), nil
+			}
+		case "email":
+			s := fmt.Sprint(v.Interface())
+			if addr, err := mail.ParseAddress(s); err != nil || addr.Address != s {
+				return "must be a valid email address", nil
+			}
+		case "oneof":
+			options := strings.Fields(param)
+			s := fmt.Sprint(v.Interface())
+			found := false
+			for _, option := range options {
+				if option == s {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return "must be one of " + strings.Join(options, ", "), nil
+			}
+		case "regex":
+			pattern, err := compilePattern(param)
+			if err != nil {
+				return "", err
+			}
+			if !pattern.MatchString(fmt.Sprint(v.Interface())) {
+				return "has invalid format", nil
+			}
+		default:
+			return "", fmt.Errorf("unknown rule %q", name)
+		}
+	}
+	return "", nil
+}
+
+// measure returns the size of value used by min and max rules with its unit.
+func measure(v reflect.Value) (float64, string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), " characters", true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), " items", true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), "", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), "", true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), "", true
+	}
+	return 0, "", false
+}
+
+// compilePattern compiles the regex rule pattern once.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := patterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(pattern, compiled)
+	return compiled, nil
+}
+
+// fieldName returns the name of field used in field failure paths.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "xml", "query", "params", "reqHeader"} {
+		if name := tagName(field, tag); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
@@ -0,0 +1,84 @@
+package content
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mekramy/gohttp"
+)
+
+func TestValidateField(t *testing.T) {
+	long := "abcd"
+	short := "ab"
+	tests := []struct {
+		name    string
+		value   any
+		tag     string
+		message string
+		invalid bool
+	}{
+		{"required empty", "", "required", "is required", false},
+		{"required value", "a", "required", "", false},
+		{"required zero number", 0, "required,min=1", "is required", false},
+		{"omitempty empty", "", "omitempty,email", "", false},
+		{"omitempty value", "invalid", "omitempty,email", "must be a valid email address", false},
+		{"email empty", "", "email", "must be a valid email address", false},
+		{"email valid", "user@example.com", "email", "", false},
+		{"email with name", "User <user@example.com>", "email", "must be a valid email address", false},
+		{"min zero number", 0, "min=1", "must be at least 1", false},
+		{"min empty slice", []string{}, "min=1", "must be at least 1 items", false},
+		{"min nil slice", []string(nil), "min=1", "must be at least 1 items", false},
+		{"min zero omitempty", 0, "omitempty,min=1", "", false},
+		{"max number", 11, "max=10", "must be at most 10", false},
+		{"max float", 1.5, "max=1.25", "must be at most 1.25", false},
+		{"max unsigned", uint8(3), "min=1,max=5", "", false},
+		{"max string", "abcd", "max=3", "must be at most 3 characters", false},
+		{"max string runes", "héé", "max=3", "", false},
+		{"max map", map[string]int{"a": 1, "b": 2}, "max=1", "must be at most 1 items", false},
+		{"max nil pointer", (*string)(nil), "max=3", "", false},
+		{"max pointer", &long, "max=3", "must be at most 3 characters", false},
+		{"min pointer", &short, "min=1,max=3", "", false},
+		{"oneof valid", "b", "oneof=a b c", "", false},
+		{"oneof invalid", "d", "oneof=a b c", "must be one of a, b, c", false},
+		{"oneof number", 2, "oneof=1 2", "", false},
+		{"regex with comma", "abc", "regex=^[a-z]{1,3}$", "", false},
+		{"regex with comma invalid", "abcd", "regex=^[a-z]{1,3}$", "has invalid format", false},
+		{"regex after rules", "12", "required, min=2, regex=^\\d{2,4}$", "", false},
+		{"first failed rule", "abcd", "min=5,max=3", "must be at least 5 characters", false},
+		{"unknown rule", "a", "unknown", "", true},
+		{"invalid limit", "a", "min=x", "", true},
+		{"unsupported type", true, "min=1", "", true},
+		{"invalid regex", "a", "regex=[", "", true},
+	}
+
+	for _, test := range tests {
+		message, err := validateField(reflect.ValueOf(test.value), test.tag)
+		if (err != nil) != test.invalid {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		if message != test.message {
+			t.Errorf("%s: got %q, expected %q", test.name, message, test.message)
+		}
+	}
+}
+
+func TestValidateCaller(t *testing.T) {
+	type form struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	err := Validate(nil, &form{})
+	var he gohttp.HttpError
+	if !errors.As(err, &he) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if filepath.Base(he.File) != "validate_test.go" {
+		t.Errorf("expected caller file validate_test.go, got %s", he.File)
+	}
+	if len(he.Fields) != 1 || he.Fields[0].Field != "name" {
+		t.Errorf("unexpected field failures %v", he.Fields)
+	}
+}
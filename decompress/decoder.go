@@ -0,0 +1,56 @@
+package decompress
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// decoders are the supported content encoding decoders.
+// Limit is the maximum decompressed size used to bound decoder memory.
+var decoders = map[string]func(r io.Reader, limit int64) (io.ReadCloser, error){
+	"gzip":   newGzip,
+	"x-gzip": newGzip,
+	"deflate": func(r io.Reader, _ int64) (io.ReadCloser, error) {
+		// Deflate coding is zlib format (RFC 1950) but some clients send raw deflate data.
+		buffered := bufio.NewReader(r)
+		header, err := buffered.Peek(2)
+		if err == nil && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 && header[0]&0x0f == 8 {
+			return zlib.NewReader(buffered)
+		}
+		return flate.NewReader(buffered), nil
+	},
+	"br": func(r io.Reader, _ int64) (io.ReadCloser, error) {
+		return io.NopCloser(brotli.NewReader(r)), nil
+	},
+	"zstd": func(r io.Reader, limit int64) (io.ReadCloser, error) {
+		// Frames declaring a window larger than limit are rejected before allocation.
+		decoder, err := zstd.NewReader(
+			r,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderLowmem(true),
+			zstd.WithDecoderMaxMemory(uint64(max(limit, 1))),
+			zstd.WithDecoderMaxWindow(uint64(max(limit, zstd.MinWindowSize))),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	},
+}
+
+// newGzip creates a new gzip decoder.
+func newGzip(r io.Reader, _ int64) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// isLimitError checks if the decoder error is caused by exceeding memory limits.
+func isLimitError(err error) bool {
+	return errors.Is(err, zstd.ErrWindowSizeExceeded) || errors.Is(err, zstd.ErrDecoderSizeExceeded)
+}
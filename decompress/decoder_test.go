@@ -0,0 +1,43 @@
+package decompress
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestZstdDecode(t *testing.T) {
+	var buf bytes.Buffer
+	encoder, _ := zstd.NewWriter(&buf)
+	encoder.Write([]byte(`{"name":"gohttp"}`))
+	encoder.Close()
+
+	decoded, err := decode("zstd", buf.Bytes(), 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decoded) != `{"name":"gohttp"}` {
+		t.Fatalf("unexpected decoded body %q", decoded)
+	}
+}
+
+func TestZstdLargeWindow(t *testing.T) {
+	// Frame header declares 512MB window followed by a 16 bytes raw last block.
+	frame := []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00, 19 << 3, 16<<3 | 1, 0x00, 0x00}
+	frame = append(frame, bytes.Repeat([]byte{'a'}, 16)...)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	_, err := decode("zstd", frame, 1<<20)
+	runtime.ReadMemStats(&after)
+
+	if !errors.Is(err, errTooLarge) {
+		t.Fatalf("expected errTooLarge, got %v", err)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 16<<20 {
+		t.Fatalf("decoder allocated %d bytes", allocated)
+	}
+}
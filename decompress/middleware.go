@@ -0,0 +1,124 @@
+// Package decompress provides a middleware that decompresses request bodies with zip bomb protection.
+package decompress
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mekramy/gohttp"
+)
+
+// errTooLarge is returned when decompressed body exceeds the limit.
+var errTooLarge = errors.New("decompressed body exceeds limit")
+
+// NewMiddleware creates a new request body decompression middleware for Fiber framework.
+// Bodies with gzip, deflate, br or zstd Content-Encoding (including multiple codings) are decompressed
+// and the Content-Encoding header is removed, so the middleware composes ahead of content middlewares
+// and body parsers. Decompressed size and compression ratio are limited to block zip bombs.
+// Unsupported encodings produce a 415 Unsupported Media Type gohttp.HttpError with the supported
+// encodings in the Accept-Encoding header, bodies exceeding limits produce 413 Request Entity Too Large
+// and malformed bodies produce 400 Bad Request errors.
+func NewMiddleware(options ...Options) fiber.Handler {
+	// Generate option
+	option := &Option{
+		next:      nil,
+		maxSize:   fiber.DefaultBodyLimit,
+		maxRatio:  100,
+		encodings: []string{"gzip", "deflate", "br", "zstd"},
+	}
+	for _, opt := range options {
+		opt(option)
+	}
+
+	supported := make(map[string]bool)
+	advertised := make([]string, 0, len(option.encodings))
+	for _, encoding := range option.encodings {
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+		if encoding == "x-gzip" {
+			encoding = "gzip"
+		}
+		if _, ok := decoders[encoding]; ok && !supported[encoding] {
+			supported[encoding] = true
+			advertised = append(advertised, encoding)
+		}
+	}
+	if supported["gzip"] {
+		supported["x-gzip"] = true
+	}
+	accepted := strings.Join(advertised, ", ")
+
+	return func(c *fiber.Ctx) error {
+		// Skip
+		if option.next != nil && option.next(c) {
+			return c.Next()
+		}
+
+		encodings := parseEncodings(c.Get(fiber.HeaderContentEncoding))
+		if len(encodings) == 0 || len(c.Request().Body()) == 0 {
+			return c.Next()
+		}
+
+		for _, encoding := range encodings {
+			if !supported[encoding] {
+				return gohttp.UnsupportedMediaType("Unsupported Content-Encoding "+encoding).
+					WithHeader(fiber.HeaderAcceptEncoding, accepted)
+			}
+		}
+
+		// Decompress in reverse order of applied codings
+		body := c.Request().Body()
+		limit := min(option.maxSize, int64(float64(len(body))*option.maxRatio))
+		for i := len(encodings) - 1; i >= 0; i-- {
+			decoded, err := decode(encodings[i], body, limit)
+			if errors.Is(err, errTooLarge) {
+				return gohttp.RequestEntityTooLarge("Decompressed body too large")
+			} else if err != nil {
+				return gohttp.BadRequest("Malformed " + encodings[i] + " body").WithCause(err)
+			}
+			body = decoded
+		}
+
+		c.Request().SetBody(body)
+		c.Request().Header.Del(fiber.HeaderContentEncoding)
+		c.Request().Header.SetContentLength(len(body))
+		return c.Next()
+	}
+}
+
+// decode decompresses the body using encoding decoder.
+// It returns errTooLarge if decompressed body exceeds limit bytes.
+func decode(encoding string, body []byte, limit int64) ([]byte, error) {
+	reader, err := decoders[encoding](bytes.NewReader(body), limit)
+	if isLimitError(err) {
+		return nil, errTooLarge
+	} else if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	decoded, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if isLimitError(err) {
+		return nil, errTooLarge
+	} else if err != nil {
+		return nil, err
+	}
+	if int64(len(decoded)) > limit {
+		return nil, errTooLarge
+	}
+	return decoded, nil
+}
+
+// parseEncodings parses the lower-cased codings of Content-Encoding header ignoring identity.
+func parseEncodings(header string) []string {
+	res := make([]string, 0)
+	for _, encoding := range strings.Split(header, ",") {
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+		if encoding != "" && encoding != "identity" {
+			res = append(res, encoding)
+		}
+	}
+	return res
+}
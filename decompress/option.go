@@ -0,0 +1,49 @@
+package decompress
+
+import "github.com/gofiber/fiber/v2"
+
+// Options defines a function type for configuring Decompress Option.
+type Options func(*Option)
+
+// Option holds the configuration options for Decompress middleware.
+type Option struct {
+	next      func(*fiber.Ctx) bool // Function to skip decompression for certain requests
+	maxSize   int64                 // Maximum decompressed body size in bytes
+	maxRatio  float64               // Maximum decompressed to compressed size ratio
+	encodings []string              // Supported content encodings
+}
+
+// WithNext sets a custom function to skip decompression for certain requests.
+func WithNext(handler func(*fiber.Ctx) bool) Options {
+	return func(c *Option) {
+		c.next = handler
+	}
+}
+
+// WithMaxSize sets the maximum decompressed body size in bytes.
+// Default is fiber.DefaultBodyLimit (4MB).
+func WithMaxSize(size int64) Options {
+	return func(c *Option) {
+		if size > 0 {
+			c.maxSize = size
+		}
+	}
+}
+
+// WithMaxRatio sets the maximum ratio of decompressed to compressed body size.
+// Default is 100.
+func WithMaxRatio(ratio float64) Options {
+	return func(c *Option) {
+		if ratio > 0 {
+			c.maxRatio = ratio
+		}
+	}
+}
+
+// WithEncodings sets the supported content encodings.
+// Valid encodings are "gzip", "deflate", "br" and "zstd". All of them are supported by default.
+func WithEncodings(encodings ...string) Options {
+	return func(c *Option) {
+		c.encodings = encodings
+	}
+}
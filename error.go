@@ -1,9 +1,13 @@
 package gohttp
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"mime"
 	"mime/multipart"
 	"runtime"
+	"strings"
 
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/gofiber/fiber/v2"
@@ -12,11 +16,24 @@ import (
 
 // HttpError represents an HTTP error with additional context information.
 type HttpError struct {
-	Line    int            // Line number where the error occurred.
-	File    string         // File name where the error occurred.
-	Body    map[string]any // Request body data.
-	Status  int            // HTTP status code.
-	Message string         // Error message.
+	Line       int               // Line number where the error occurred.
+	File       string            // File name where the error occurred.
+	Body       map[string]any    // Request body data.
+	Status     int               // HTTP status code.
+	Message    string            // Error message.
+	Code       string            // Machine-readable error code.
+	Params     map[string]any    // Error code message params.
+	Type       string            // Problem type URI (RFC 9457), defaults to "about:blank".
+	Extensions map[string]any    // Problem extension members (RFC 9457).
+	Meta       map[string]any    // Internal metadata, logged but not rendered.
+	Headers    map[string]string // Response headers applied by error handler.
+	Cause      error             // Underlying error.
+	Fields     []FieldError      // Field-level validation failures.
+	Stack      []Frame           // Full call stack, captured when CaptureStack is enabled.
+	Public     bool              // Expose message of 5xx errors in production mode.
+	Reference  string            // Opaque reference ID of errors hidden in production mode.
+	RequestID  string            // Request ID assigned by requestid middleware.
+	Errors     []HttpError       // Sub-errors of joined errors.
 }
 
 // Error returns the error message.
@@ -24,9 +41,14 @@ func (he HttpError) Error() string {
 	return he.Message
 }
 
+// Unwrap returns the underlying cause of the error.
+func (he HttpError) Unwrap() error {
+	return he.Cause
+}
+
 // NewError creates a new HttpError with the provided error message and optional status code.
 // If no status code is provided, it defaults to 500.
-// It also captures the file and line number where the error occurred.
+// It also captures the file and line number where the error occurred and the call stack if CaptureStack is enabled.
 func NewError(e string, status ...int) error {
 	code := 500
 	if len(status) > 0 {
@@ -40,17 +62,101 @@ func NewError(e string, status ...int) error {
 		Body:    nil,
 		Status:  code,
 		Message: e,
+		Stack:   callerStack(),
+	}
+}
+
+// Public marks the HttpError as public, so its message is exposed to clients in production mode.
+// Errors other than HttpError are returned unchanged.
+func Public(err error) error {
+	if he, ok := err.(HttpError); ok {
+		he.Public = true
+		return he
+	}
+	return err
+}
+
+// Wrap creates a new HttpError with the provided message and optional status code that keeps err as its cause.
+// If no status code is provided, it defaults to 500. Wrap returns nil if err is nil.
+// It also captures the file and line number where the error occurred and the call stack if CaptureStack is enabled.
+func Wrap(err error, e string, status ...int) error {
+	if err == nil {
+		return nil
+	}
+
+	code := 500
+	if len(status) > 0 {
+		code = status[0]
+	}
+
+	file, line, _ := realCaller()
+	return HttpError{
+		Line:    line,
+		File:    file,
+		Body:    nil,
+		Status:  code,
+		Message: e,
+		Cause:   err,
+		Stack:   callerStack(),
+	}
+}
+
+// NewPanicError creates a new HttpError from the recovered panic value and optional status code.
+// If no status code is provided, it defaults to 500. If the panic value is an error, it is kept as cause.
+// It captures the file and line number of the panicking function and the full call stack.
+// It must be called directly from the deferred function that recovered the panic.
+func NewPanicError(value any, status ...int) error {
+	code := 500
+	if len(status) > 0 {
+		code = status[0]
+	}
+
+	var file string
+	var line int
+	stack := panicStack()
+	if len(stack) > 0 {
+		file = stack[0].File
+		line = stack[0].Line
+	}
+
+	cause, _ := value.(error)
+	return HttpError{
+		Line:    line,
+		File:    file,
+		Body:    nil,
+		Status:  code,
+		Message: fmt.Sprintf("panic: %v", value),
+		Cause:   cause,
+		Stack:   stack,
 	}
 }
 
 // NewFormError creates a new HttpError with the provided error message, request context, and optional status code.
-// It captures the file and line number where the error occurred and includes request body data if available.
+// It captures the file and line number where the error occurred, the call stack if CaptureStack is enabled
+// and includes request snapshot if available. Request snapshot is configured by SetSnapshot and
+// redacted using the policy configured by SetRedaction.
 func NewFormError(e string, ctx *fiber.Ctx, status ...int) error {
 	code := 500
 	if len(status) > 0 {
 		code = status[0]
 	}
 
+	file, line, _ := realCaller()
+	return HttpError{
+		Line:    line,
+		File:    file,
+		Body:    formBody(ctx),
+		Status:  code,
+		Message: e,
+		Stack:   callerStack(),
+	}
+}
+
+// formBody extracts the request form values, uploaded files information, query and route parameters
+// and allowed headers of the provided context. Nested JSON values are flattened using dotted keys.
+// Captured data is redacted using the policy configured by SetRedaction and truncated to the
+// size budget configured by SetSnapshot.
+func formBody(ctx *fiber.Ctx) map[string]any {
 	var body map[string]any
 	if ctx != nil {
 		body = make(map[string]any)
@@ -82,6 +188,15 @@ func NewFormError(e string, ctx *fiber.Ctx, status ...int) error {
 					body["file."+k] = values
 				}
 			}
+		} else if isJSON(ctx.Get(fiber.HeaderContentType)) {
+			var form any
+			if len(ctx.Body()) == 0 {
+				body["form"] = nil
+			} else if err := json.Unmarshal(ctx.Body(), &form); err != nil {
+				body["form"] = err.Error()
+			} else {
+				flattenValue(body, "form", form)
+			}
 		} else {
 			var form map[string]any
 			if err := ctx.BodyParser(&form); err != nil {
@@ -90,20 +205,26 @@ func NewFormError(e string, ctx *fiber.Ctx, status ...int) error {
 				body["form"] = nil
 			} else {
 				for k, v := range form {
-					body["form."+k] = v
+					flattenValue(body, "form."+k, v)
 				}
 			}
 		}
+
+		requestSnapshot(ctx, body)
 	}
 
-	file, line, _ := realCaller()
-	return HttpError{
-		Line:    line,
-		File:    file,
-		Body:    body,
-		Status:  code,
-		Message: e,
+	return truncateBody(redact(body))
+}
+
+// isJSON checks if the content type is JSON media type (application/json or +json suffix).
+func isJSON(contentType string) bool {
+	typ, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
 	}
+
+	_, sub, _ := strings.Cut(typ, "/")
+	return sub == "json" || strings.HasSuffix(sub, "+json")
 }
 
 // detectMime detects the MIME type of the provided file header.
@@ -121,6 +242,35 @@ func detectMime(file *multipart.FileHeader) string {
 	return "?"
 }
 
+// causeChain returns the messages of the error and all of its underlying causes.
+func causeChain(err error) []string {
+	res := make([]string, 0)
+	for err != nil {
+		res = append(res, err.Error())
+		err = errors.Unwrap(err)
+	}
+	return res
+}
+
+// newHttpError creates a new HttpError capturing the caller of the exported constructor that calls it.
+func newHttpError(e string, status int) HttpError {
+	he := HttpError{
+		Status:  status,
+		Message: e,
+	}
+
+	if _, f, l, ok := runtime.Caller(2); ok {
+		he.File = f
+		he.Line = l
+	}
+
+	if captureStack.Load() {
+		he.Stack = stackFrom(3)
+	}
+
+	return he
+}
+
 // realCaller returns the file name and line number of error caller func.
 func realCaller() (string, int, bool) {
 	if _, f, l, ok := runtime.Caller(2); ok {
@@ -1,11 +1,14 @@
 package gohttp
 
 import (
-	"os"
-	"path/filepath"
+	"errors"
+	"fmt"
 	"slices"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/google/uuid"
+	"github.com/mekramy/gohttp/requestid"
 	"github.com/mekramy/gologger"
 )
 
@@ -15,71 +18,310 @@ type ErrorCallback func(ctx *fiber.Ctx, err HttpError) error
 // NewFiberErrorHandler creates a new Fiber error handler with logging and custom error response capabilities.
 // It takes a logger, an optional error callback, and a list of status codes to log.
 // If the error matches one of the provided status codes, it will be logged using the provided logger.
-// If an error callback is provided, it will be used to handle the error response; otherwise, a default plain text response will be sent.
+// Use NewErrorHandler with WithLogPolicy for per-status log levels, status ranges and per-route rules.
+// If an error callback is provided, it will be used to handle the error response; otherwise, the response format
+// (JSON, XML, HTML or plain text) is negotiated from the request Accept header using NegotiateCallback defaults.
+// HttpError and fiber.Error are detected anywhere in the error chain and the full cause chain is logged.
+// Other errors are mapped using the classifiers registered by RegisterClassifier.
+// Request ID assigned by requestid middleware is logged and passed to error callback.
+// Joined errors (errors.Join) are aggregated, every sub-error is logged and passed to error callback.
+// Use ProblemCallback as error callback to render RFC 9457 application/problem+json responses.
 // For relative file name in log use os.Setenv("APP_ROOT", "your/project/root") to define your project root.
 func NewFiberErrorHandler(l gologger.Logger, cb ErrorCallback, codes ...int) fiber.ErrorHandler {
-	relative := func(path string) string {
-		root := filepath.ToSlash(os.Getenv("APP_ROOT"))
-		path = filepath.ToSlash(path)
-		if root != "" {
-			if p, err := filepath.Rel(root, path); err == nil {
-				return p
-			}
-		}
+	return NewErrorHandler(l, cb, WithCodes(codes...))
+}
+
+// NewErrorHandler creates a new Fiber error handler like NewFiberErrorHandler configured by the provided options.
+func NewErrorHandler(l gologger.Logger, cb ErrorCallback, options ...ErrorOptions) fiber.ErrorHandler {
+	// Generate option
+	option := &ErrorOption{
+		policy:     NewLogPolicy(),
+		precedence: HighestStatus,
+		dev:        false,
+		production: false,
+		message:    "Internal Server Error",
+		reference:  uuid.NewString,
+	}
+	for _, opt := range options {
+		opt(option)
+	}
 
-		return filepath.ToSlash(path)
+	if cb == nil {
+		cb = NegotiateCallback()
 	}
 
 	return func(ctx *fiber.Ctx, err error) error {
 		// Parse error
-		file := ""
-		line := 0
-		var body map[string]any
-		status := fiber.StatusInternalServerError
-		message := "Internal Server Error"
-
-		if fe, ok := err.(*fiber.Error); ok {
-			status = fe.Code
-			message = fe.Error()
+		herr, wrapper := parseError(err)
+		if errs := joinedErrors(err); len(errs) > 1 {
+			subs := make([]HttpError, len(errs))
+			for i, e := range errs {
+				subs[i], _ = parseError(e)
+			}
+			herr, wrapper = aggregate(subs, option.precedence), nil
 		}
 
-		if he, ok := err.(HttpError); ok {
-			line = he.Line
-			file = he.File
-			message = he.Error()
-			status = he.Status
-			body = he.Body
+		// Sub-errors slice is owned by the caller, so it is cloned before modification
+		herr.Errors = slices.Clone(herr.Errors)
+		id := requestid.Parse(ctx)
+		herr.Stack = relativeStack(herr.Stack)
+		herr.RequestID = id
+		for i := range herr.Errors {
+			herr.Errors[i].Stack = relativeStack(herr.Errors[i].Stack)
+			herr.Errors[i].RequestID = id
 		}
 
-		// Log
-		if l != nil && (len(codes) == 0 || slices.Contains(codes, status)) {
-			params := make([]gologger.LogOptions, 0)
-			params = append(params, gologger.With("file", relative(file)))
-			params = append(params, gologger.With("line", line))
-			params = append(params, gologger.With("status", status))
-			params = append(params, gologger.With("ip", ctx.IP()))
-			params = append(params, gologger.With("path", ctx.Path()))
-			params = append(params, gologger.With("method", ctx.Method()))
-			params = append(params, gologger.WithMessage(message))
-			for k, v := range body {
-				params = append(params, gologger.With(k, v))
+		// Generate reference of hidden errors
+		if option.isHidden(herr) || slices.ContainsFunc(herr.Errors, option.isHidden) {
+			reference := option.reference()
+			herr.Reference = reference
+			for i := range herr.Errors {
+				if option.isHidden(herr.Errors[i]) {
+					herr.Errors[i].Reference = reference
+				}
+			}
+		}
 
+		// Log
+		if l != nil {
+			if len(herr.Errors) == 0 {
+				logError(ctx, l, option, herr, wrapper)
+			}
+			for _, sub := range herr.Errors {
+				logError(ctx, l, option, sub, nil)
 			}
-			l.Error(params...)
+		}
+
+		// Report
+		if option.reports != nil {
+			option.reports.Push(NewErrorReport(ctx, herr))
 		}
 
 		// Return error
-		if cb != nil {
-			return cb(ctx, HttpError{
-				Line:    line,
-				File:    file,
-				Body:    body,
-				Status:  status,
-				Message: message,
-			})
+		for k, v := range herr.Headers {
+			ctx.Set(k, v)
+		}
+		return cb(ctx, publicError(ctx, option, herr))
+	}
+}
+
+// parseError detects the HttpError of the error.
+// It returns the error that wraps the detected HttpError if any.
+func parseError(err error) (HttpError, error) {
+	var he HttpError
+	var fe *fiber.Error
+	if errors.As(err, &he) {
+		if _, ok := err.(HttpError); !ok {
+			return he, err
+		}
+		return he, nil
+	}
+
+	herr := HttpError{
+		Status:  fiber.StatusInternalServerError,
+		Message: "Internal Server Error",
+	}
+	if errors.As(err, &fe) {
+		herr.Status = fe.Code
+		herr.Message = fe.Error()
+		if err != fe {
+			herr.Cause = err
+		}
+	} else if ce, ok := Classify(err); ok {
+		herr = ce
+	} else {
+		herr.Cause = err
+	}
+	return herr, nil
+}
+
+// joinedErrors returns the flattened sub-errors of errors joined by errors.Join or fmt.Errorf.
+func joinedErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+
+	res := make([]error, 0)
+	for _, e := range joined.Unwrap() {
+		if e == nil {
+			continue
+		}
+
+		if sub := joinedErrors(e); sub != nil {
+			res = append(res, sub...)
 		} else {
-			ctx.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
-			return ctx.Status(status).SendString(message)
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// aggregate creates the HttpError of multiple errors.
+// The first sub-error with the status resolved by precedence is used as the main error.
+func aggregate(errs []HttpError, precedence Precedence) HttpError {
+	statuses := make([]int, len(errs))
+	for i, e := range errs {
+		statuses[i] = e.Status
+	}
+	status := precedence(statuses)
+
+	herr := HttpError{
+		Status:  status,
+		Message: utils.StatusMessage(status),
+	}
+	for _, e := range errs {
+		if e.Status == status {
+			herr = e
+			break
+		}
+	}
+	herr.Errors = errs
+	return herr
+}
+
+// logError logs the error using the log policy and throttle.
+func logError(ctx *fiber.Ctx, l gologger.Logger, option *ErrorOption, herr HttpError, wrapper error) {
+	level := option.policy.Level(ctx, herr.Status)
+	if level == LogSkip {
+		return
+	}
+
+	if option.throttle != nil {
+		key := fmt.Sprintf("%s:%d:%d:%s", herr.File, herr.Line, herr.Status, herr.Message)
+		allowed := option.throttle.allow(key, func(suppressed int64) {
+			params := callerParams(herr)
+			params = append(params, gologger.With("status", herr.Status))
+			params = append(params, gologger.With("error", herr.Message))
+			params = append(params, gologger.With("suppressed", suppressed))
+			params = append(params, gologger.WithMessage(fmt.Sprintf(
+				"suppressed %d duplicates in the last %s",
+				suppressed, option.throttle.window,
+			)))
+			logAt(l, level, params...)
+		})
+		if !allowed {
+			// Reference of suppressed error is logged in short form to keep it traceable
+			if herr.Reference != "" {
+				params := callerParams(herr)
+				params = append(params, gologger.With("status", herr.Status))
+				if herr.RequestID != "" {
+					params = append(params, gologger.With("request_id", herr.RequestID))
+				}
+				params = append(params, gologger.With("reference", herr.Reference))
+				params = append(params, gologger.WithMessage("throttled duplicate: "+herr.Message))
+				logAt(l, level, params...)
+			}
+			return
+		}
+	}
+
+	logAt(l, level, logParams(ctx, herr, wrapper)...)
+}
+
+// publicError prepares the error passed to error callback.
+// It removes call stack out of development mode, hides internal messages in production mode
+// and translates messages. Sub-errors are prepared into a new slice, so the reported error is not modified.
+func publicError(ctx *fiber.Ctx, option *ErrorOption, herr HttpError) HttpError {
+	hidden := option.isHidden(herr)
+	if !option.dev {
+		herr.Stack = nil
+	}
+	if hidden {
+		herr.Message = option.message
+	}
+	if option.translator != nil {
+		herr = translate(ctx, option, herr, hidden)
+	}
+	if len(herr.Errors) > 0 {
+		errs := make([]HttpError, len(herr.Errors))
+		for i, sub := range herr.Errors {
+			errs[i] = publicError(ctx, option, sub)
+		}
+		herr.Errors = errs
+	}
+	return herr
+}
+
+// translate translates the error message and field failure messages using the translator.
+func translate(ctx *fiber.Ctx, option *ErrorOption, err HttpError, hidden bool) HttpError {
+	// Resolve locale
+	locale := ""
+	for _, resolver := range option.locales {
+		if locale = resolver(ctx); locale != "" {
+			break
 		}
 	}
+
+	// Translate message
+	key := err.Message
+	if err.Code != "" && !hidden {
+		key = err.Code
+	}
+	if msg, ok := option.translator.Translate(locale, key, err.Params); ok {
+		err.Message = msg
+	}
+
+	// Translate fields
+	if len(err.Fields) > 0 {
+		fields := make([]FieldError, len(err.Fields))
+		for i, field := range err.Fields {
+			if msg, ok := option.translator.Translate(locale, field.Message, err.Params); ok {
+				field.Message = msg
+			}
+			fields[i] = field
+		}
+		err.Fields = fields
+	}
+
+	return err
+}
+
+// logParams generates the log params of the error.
+func logParams(ctx *fiber.Ctx, err HttpError, wrapper error) []gologger.LogOptions {
+	params := callerParams(err)
+	params = append(params, gologger.With("status", err.Status))
+	if err.Code != "" {
+		params = append(params, gologger.With("code", err.Code))
+	}
+	params = append(params, gologger.With("ip", utils.CopyString(ctx.IP())))
+	params = append(params, gologger.With("path", utils.CopyString(ctx.Path())))
+	params = append(params, gologger.With("method", utils.CopyString(ctx.Method())))
+	if err.RequestID != "" {
+		params = append(params, gologger.With("request_id", err.RequestID))
+	}
+	params = append(params, gologger.WithMessage(err.Message))
+	if err.Reference != "" {
+		params = append(params, gologger.With("reference", err.Reference))
+	}
+	if wrapper != nil {
+		params = append(params, gologger.With("error", wrapper.Error()))
+	}
+	if causes := causeChain(err.Cause); len(causes) > 0 {
+		params = append(params, gologger.With("causes", causes))
+	}
+	if len(err.Stack) > 0 {
+		params = append(params, gologger.With("stack", stackStrings(err.Stack)))
+	}
+	for k, v := range err.Meta {
+		params = append(params, gologger.With("meta."+k, v))
+	}
+	for _, field := range err.Fields {
+		params = append(params, gologger.With("invalid."+field.Field, field.Message))
+	}
+	for k, v := range err.Body {
+		params = append(params, gologger.With(k, v))
+	}
+	return params
+}
+
+// callerParams generates the file and line log params of the error.
+// Errors without caller (e.g. created by middlewares) have no file and line params.
+func callerParams(err HttpError) []gologger.LogOptions {
+	params := make([]gologger.LogOptions, 0)
+	if err.File != "" {
+		params = append(params, gologger.With("file", relativePath(err.File)))
+		params = append(params, gologger.With("line", err.Line))
+	}
+	return params
 }
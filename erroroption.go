@@ -0,0 +1,158 @@
+package gohttp
+
+import (
+	"slices"
+	"time"
+
+	"github.com/mekramy/gocache"
+)
+
+// ErrorOptions defines a function type for configuring ErrorOption.
+type ErrorOptions func(*ErrorOption)
+
+// ErrorOption holds the configuration options for error handler.
+type ErrorOption struct {
+	policy     *LogPolicy    // Log level policy.
+	precedence Precedence    // Status precedence of joined errors.
+	dev        bool          // Expose call stack in error responses.
+	production bool          // Hide internal message of 5xx errors.
+	message    string        // Public message of hidden errors.
+	reference  func() string // Reference ID generator of hidden errors.
+
+	throttle *throttle    // Duplicate error log throttle.
+	reports  *ReportQueue // Error reporters queue.
+
+	translator Translator       // Error message translator.
+	locales    []LocaleResolver // Request locale resolvers.
+}
+
+// WithCodes sets the status codes to log at error level, other status codes are skipped.
+// If no status code is provided, all errors are logged.
+func WithCodes(codes ...int) ErrorOptions {
+	return func(o *ErrorOption) {
+		if len(codes) == 0 {
+			o.policy = NewLogPolicy()
+			return
+		}
+
+		rules := make([]LogRule, len(codes))
+		for i, code := range codes {
+			rules[i] = LogStatus(code, LogError)
+		}
+		o.policy = NewLogPolicy(rules...).Fallback(LogSkip)
+	}
+}
+
+// WithLogPolicy sets the log level policy of errors.
+// By default all errors are logged at error level.
+func WithLogPolicy(policy *LogPolicy) ErrorOptions {
+	return func(o *ErrorOption) {
+		if policy != nil {
+			o.policy = policy
+		}
+	}
+}
+
+// WithDevelopment enables development mode.
+// In development mode the captured call stack is passed to error callback and exposed in responses.
+func WithDevelopment() ErrorOptions {
+	return func(o *ErrorOption) {
+		o.dev = true
+	}
+}
+
+// WithProduction enables production mode.
+// In production mode the message of 5xx errors not marked as public is replaced with a generic
+// public message and an opaque reference ID. The full message is logged next to the reference ID.
+func WithProduction() ErrorOptions {
+	return func(o *ErrorOption) {
+		o.production = true
+	}
+}
+
+// WithPublicMessage sets the generic message of errors hidden in production mode.
+func WithPublicMessage(message string) ErrorOptions {
+	return func(o *ErrorOption) {
+		if message != "" {
+			o.message = message
+		}
+	}
+}
+
+// WithReferenceGenerator sets the reference ID generator of errors hidden in production mode.
+func WithReferenceGenerator(generator func() string) ErrorOptions {
+	return func(o *ErrorOption) {
+		if generator != nil {
+			o.reference = generator
+		}
+	}
+}
+
+// WithTranslator sets the translator of error messages passed to error callback.
+// Error code is used as translation key if set, otherwise the message is used.
+// Locale resolved by the first resolver returns non-empty locale, translator default language is used otherwise.
+func WithTranslator(translator Translator, resolvers ...LocaleResolver) ErrorOptions {
+	return func(o *ErrorOption) {
+		o.translator = translator
+		o.locales = resolvers
+	}
+}
+
+// WithThrottle enables deduplication of repeated error logs.
+// Errors with the same file, line, status and message are logged once per window
+// (windows are aligned to the window duration) and a summary of suppressed duplicates is logged when the window closes.
+// Throttle state stored in the provided cache, so it can be shared across instances.
+// Shared caches should implement CacheAdder to open windows atomically across instances.
+// In-memory cache is used if cache is nil. Suppressed errors with reference ID (production mode)
+// are logged in short form with their file, line, status, reference and request ID.
+func WithThrottle(window time.Duration, cache gocache.Cache) ErrorOptions {
+	return func(o *ErrorOption) {
+		if window <= 0 {
+			return
+		}
+
+		if cache == nil {
+			cache = gocache.NewMemoryCache()
+		}
+		o.throttle = &throttle{
+			window: window,
+			cache:  cache,
+		}
+	}
+}
+
+// WithReporters sets the queue of error reporters.
+// Every handled error is pushed to the queue with its request metadata.
+func WithReporters(queue *ReportQueue) ErrorOptions {
+	return func(o *ErrorOption) {
+		o.reports = queue
+	}
+}
+
+// WithPrecedence sets the status precedence rule of joined errors.
+// HighestStatus is used by default.
+func WithPrecedence(precedence Precedence) ErrorOptions {
+	return func(o *ErrorOption) {
+		if precedence != nil {
+			o.precedence = precedence
+		}
+	}
+}
+
+// isHidden checks if the message of error must be hidden in production mode.
+func (o *ErrorOption) isHidden(err HttpError) bool {
+	return o.production && err.Status >= 500 && !err.Public
+}
+
+// Precedence is a function type that resolves the response status of joined errors from sub-error statuses.
+type Precedence func(statuses []int) int
+
+// HighestStatus is a Precedence that resolves the highest status code.
+func HighestStatus(statuses []int) int {
+	return slices.Max(statuses)
+}
+
+// FirstStatus is a Precedence that resolves the status code of the first error.
+func FirstStatus(statuses []int) int {
+	return statuses[0]
+}
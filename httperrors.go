@@ -0,0 +1,133 @@
+package gohttp
+
+import (
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// BadRequest creates a new HttpError with 400 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func BadRequest(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusBadRequest), fiber.StatusBadRequest)
+}
+
+// Unauthorized creates a new HttpError with 401 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func Unauthorized(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusUnauthorized), fiber.StatusUnauthorized)
+}
+
+// Forbidden creates a new HttpError with 403 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func Forbidden(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusForbidden), fiber.StatusForbidden)
+}
+
+// NotFound creates a new HttpError with 404 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func NotFound(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusNotFound), fiber.StatusNotFound)
+}
+
+// NotAcceptable creates a new HttpError with 406 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func NotAcceptable(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusNotAcceptable), fiber.StatusNotAcceptable)
+}
+
+// Conflict creates a new HttpError with 409 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func Conflict(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusConflict), fiber.StatusConflict)
+}
+
+// UnsupportedMediaType creates a new HttpError with 415 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func UnsupportedMediaType(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusUnsupportedMediaType), fiber.StatusUnsupportedMediaType)
+}
+
+// RequestEntityTooLarge creates a new HttpError with 413 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func RequestEntityTooLarge(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusRequestEntityTooLarge), fiber.StatusRequestEntityTooLarge)
+}
+
+// TooManyRequests creates a new HttpError with 429 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func TooManyRequests(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusTooManyRequests), fiber.StatusTooManyRequests)
+}
+
+// InternalServerError creates a new HttpError with 500 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func InternalServerError(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusInternalServerError), fiber.StatusInternalServerError)
+}
+
+// ServiceUnavailable creates a new HttpError with 503 status code.
+// Empty message defaults to status text. It captures the caller like NewError.
+func ServiceUnavailable(e string) HttpError {
+	return newHttpError(statusText(e, fiber.StatusServiceUnavailable), fiber.StatusServiceUnavailable)
+}
+
+// WithMeta returns a copy of the error with the metadata.
+// Metadata is logged but not rendered in responses.
+func (he HttpError) WithMeta(key string, value any) HttpError {
+	he.Meta = maps.Clone(he.Meta)
+	if he.Meta == nil {
+		he.Meta = make(map[string]any)
+	}
+	he.Meta[key] = value
+	return he
+}
+
+// WithExtension returns a copy of the error with the problem extension member.
+func (he HttpError) WithExtension(key string, value any) HttpError {
+	he.Extensions = maps.Clone(he.Extensions)
+	if he.Extensions == nil {
+		he.Extensions = make(map[string]any)
+	}
+	he.Extensions[key] = value
+	return he
+}
+
+// WithHeader returns a copy of the error with the response header.
+// Headers are applied by the error handler when it responds.
+func (he HttpError) WithHeader(key, value string) HttpError {
+	he.Headers = maps.Clone(he.Headers)
+	if he.Headers == nil {
+		he.Headers = make(map[string]string)
+	}
+	he.Headers[key] = value
+	return he
+}
+
+// WithRetryAfter returns a copy of the error with the Retry-After response header.
+func (he HttpError) WithRetryAfter(d time.Duration) HttpError {
+	return he.WithHeader(fiber.HeaderRetryAfter, strconv.Itoa(int(d.Round(time.Second).Seconds())))
+}
+
+// WithCause returns a copy of the error with the underlying cause.
+func (he HttpError) WithCause(err error) HttpError {
+	he.Cause = err
+	return he
+}
+
+// WithType returns a copy of the error with the problem type URI.
+func (he HttpError) WithType(uri string) HttpError {
+	he.Type = uri
+	return he
+}
+
+// statusText returns the message or status text if message is empty.
+func statusText(e string, status int) string {
+	if e == "" {
+		return utils.StatusMessage(status)
+	}
+	return e
+}
@@ -0,0 +1,115 @@
+package gohttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mekramy/gologger"
+)
+
+// LogLevel represents the log level of errors.
+type LogLevel int
+
+const (
+	LogSkip  LogLevel = iota // Do not log.
+	LogDebug                 // Log at debug level.
+	LogInfo                  // Log at info level.
+	LogWarn                  // Log at warn level.
+	LogError                 // Log at error level.
+)
+
+// LogRule maps an inclusive status code range to a log level.
+type LogRule struct {
+	Min   int      // Minimum status code.
+	Max   int      // Maximum status code.
+	Level LogLevel // Log level of matched status codes.
+}
+
+// LogStatus creates a rule for a single status code.
+func LogStatus(status int, level LogLevel) LogRule {
+	return LogRule{Min: status, Max: status, Level: level}
+}
+
+// LogRange creates a rule for an inclusive status code range.
+func LogRange(min, max int, level LogLevel) LogRule {
+	return LogRule{Min: min, Max: max, Level: level}
+}
+
+// LogClass creates a rule for a status code class, e.g. LogClass(5, LogError) for 5xx errors.
+func LogClass(class int, level LogLevel) LogRule {
+	return LogRule{Min: class * 100, Max: class*100 + 99, Level: level}
+}
+
+// LogPolicy resolves the log level of errors by status code and route.
+// Rules are checked in order and the first matching rule wins.
+// Route rules take precedence over global rules.
+type LogPolicy struct {
+	rules    []LogRule
+	routes   map[string][]LogRule
+	fallback LogLevel
+}
+
+// NewLogPolicy creates a new log policy with the provided rules.
+// Errors not matched by any rule are logged at error level.
+func NewLogPolicy(rules ...LogRule) *LogPolicy {
+	return &LogPolicy{
+		rules:    rules,
+		routes:   make(map[string][]LogRule),
+		fallback: LogError,
+	}
+}
+
+// Route adds override rules for the route pattern, e.g. "/users/:id".
+// Requests without matched route are checked against the request path.
+func (p *LogPolicy) Route(pattern string, rules ...LogRule) *LogPolicy {
+	p.routes[pattern] = append(p.routes[pattern], rules...)
+	return p
+}
+
+// Fallback sets the log level of errors not matched by any rule.
+func (p *LogPolicy) Fallback(level LogLevel) *LogPolicy {
+	p.fallback = level
+	return p
+}
+
+// Level returns the log level of the status code for the request.
+func (p *LogPolicy) Level(ctx *fiber.Ctx, status int) LogLevel {
+	if len(p.routes) > 0 {
+		pattern := ctx.Path()
+		if route := ctx.Route(); route != nil && route.Path != "" && route.Path != "/" {
+			pattern = route.Path
+		}
+
+		if level, ok := matchRule(p.routes[pattern], status); ok {
+			return level
+		}
+	}
+
+	if level, ok := matchRule(p.rules, status); ok {
+		return level
+	}
+
+	return p.fallback
+}
+
+// matchRule returns the level of the first rule matching the status code.
+func matchRule(rules []LogRule, status int) (LogLevel, bool) {
+	for _, rule := range rules {
+		if status >= rule.Min && status <= rule.Max {
+			return rule.Level, true
+		}
+	}
+	return LogSkip, false
+}
+
+// logAt logs the params using the logger method of the level.
+func logAt(l gologger.Logger, level LogLevel, params ...gologger.LogOptions) {
+	switch level {
+	case LogDebug:
+		l.Debug(params...)
+	case LogInfo:
+		l.Info(params...)
+	case LogWarn:
+		l.Warn(params...)
+	case LogError:
+		l.Error(params...)
+	}
+}
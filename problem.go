@@ -0,0 +1,108 @@
+package gohttp
+
+import (
+	"encoding/json"
+	"maps"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// MIMEApplicationProblemJSON is the media type of RFC 9457 problem details documents.
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem represents an RFC 9457 problem details document.
+type Problem struct {
+	Type       string         // A URI reference that identifies the problem type.
+	Title      string         // A short, human-readable summary of the problem type.
+	Status     int            // The HTTP status code.
+	Detail     string         // A human-readable explanation specific to this occurrence.
+	Instance   string         // A URI reference that identifies the specific occurrence.
+	Extensions map[string]any // Additional members of the problem document.
+}
+
+// NewProblem creates a problem details document from the provided HttpError.
+// The problem type defaults to "about:blank" and the instance defaults to the request path.
+// Code, reference, field failures, call stack and sub-errors are rendered as "code", "reference", "errors", "stack" and "details" extension members.
+func NewProblem(ctx *fiber.Ctx, err HttpError) Problem {
+	problem := Problem{
+		Type:       err.Type,
+		Title:      utils.StatusMessage(err.Status),
+		Status:     err.Status,
+		Detail:     err.Message,
+		Extensions: err.Extensions,
+	}
+
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+
+	if err.Code != "" {
+		problem.extend("code", err.Code)
+	}
+
+	if err.Reference != "" {
+		problem.extend("reference", err.Reference)
+	}
+
+	if len(err.Fields) > 0 {
+		problem.extend("errors", err.Fields)
+	}
+
+	if len(err.Stack) > 0 {
+		problem.extend("stack", err.Stack)
+	}
+
+	if len(err.Errors) > 0 {
+		details := make([]Problem, len(err.Errors))
+		for i, sub := range err.Errors {
+			details[i] = NewProblem(nil, sub)
+		}
+		problem.extend("details", details)
+	}
+
+	if ctx != nil {
+		problem.Instance = ctx.Path()
+	}
+
+	return problem
+}
+
+// extend adds an extension member without modifying the source extensions map.
+func (p *Problem) extend(k string, v any) {
+	p.Extensions = maps.Clone(p.Extensions)
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]any)
+	}
+	p.Extensions[k] = v
+}
+
+// MarshalJSON encodes the problem as a flat JSON object.
+// Extension members never override the standard members.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	res := make(map[string]any, len(p.Extensions)+5)
+	maps.Copy(res, p.Extensions)
+	res["type"] = p.Type
+	res["title"] = p.Title
+	res["status"] = p.Status
+	if p.Detail != "" {
+		res["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		res["instance"] = p.Instance
+	}
+
+	return json.Marshal(res)
+}
+
+// ProblemCallback is an ErrorCallback that renders the error as an
+// application/problem+json document.
+func ProblemCallback(ctx *fiber.Ctx, err HttpError) error {
+	encoded, e := json.Marshal(NewProblem(ctx, err))
+	if e != nil {
+		return e
+	}
+
+	ctx.Set(fiber.HeaderContentType, MIMEApplicationProblemJSON)
+	return ctx.Status(err.Status).Send(encoded)
+}
@@ -0,0 +1,271 @@
+package gohttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// maxUpstreamBody is the maximum size of downstream error response read by DecodeResponse.
+const maxUpstreamBody = 1 << 20
+
+// maxUpstreamSnippet is the maximum size of unrecognized response body recorded on UpstreamError.
+const maxUpstreamSnippet = 256
+
+// UpstreamError represents an error returned by another service.
+// It is recorded as the cause of HttpError decoded by DecodeError and DecodeResponse.
+type UpstreamError struct {
+	Service   string // Service name or address.
+	Status    int    // Response status code.
+	Code      string // Machine-readable error code.
+	Message   string // Error message.
+	Reference string // Reference ID generated by service.
+	RequestID string // Request ID reported by service.
+	Body      string // Truncated raw body of unrecognized error responses.
+	Cause     error  // Cause reported by service.
+}
+
+// Error returns the error in "service: status message" format.
+func (ue UpstreamError) Error() string {
+	res := fmt.Sprintf("%s: %d %s", ue.Service, ue.Status, ue.Message)
+	if ue.Reference != "" {
+		res += " (reference " + ue.Reference + ")"
+	}
+	if ue.Body != "" {
+		res += ": " + ue.Body
+	}
+	return res
+}
+
+// Unwrap returns the cause reported by service.
+func (ue UpstreamError) Unwrap() error {
+	return ue.Cause
+}
+
+// errorJSON is the wire format of HttpError.
+// Problem details members (title, detail) are accepted on decode.
+type errorJSON struct {
+	Status     int               `json:"status"`
+	Code       string            `json:"code,omitempty"`
+	Message    string            `json:"message,omitempty"`
+	Title      string            `json:"title,omitempty"`
+	Detail     string            `json:"detail,omitempty"`
+	Type       string            `json:"type,omitempty"`
+	Params     map[string]any    `json:"params,omitempty"`
+	Meta       map[string]any    `json:"meta,omitempty"`
+	Extensions map[string]any    `json:"extensions,omitempty"`
+	Fields     []FieldError      `json:"errors,omitempty"`
+	Errors     []json.RawMessage `json:"details,omitempty"`
+	Stack      []Frame           `json:"stack,omitempty"`
+	Public     bool              `json:"public,omitempty"`
+	Reference  string            `json:"reference,omitempty"`
+	RequestID  string            `json:"request_id,omitempty"`
+	Cause      string            `json:"cause,omitempty"`
+}
+
+// errorJSONKeys are the known members of the wire format.
+var errorJSONKeys = map[string]bool{
+	"status": true, "code": true, "message": true, "title": true, "detail": true,
+	"type": true, "params": true, "meta": true, "extensions": true, "errors": true,
+	"details": true, "stack": true, "public": true, "reference": true,
+	"request_id": true, "cause": true, "instance": true,
+}
+
+// MarshalJSON encodes the public members of error (status, code, message, type, extensions,
+// field errors, sub-errors, reference and request id) so it is safe to render to clients.
+// Params, meta, stack and cause are not encoded, use EncodeError for service-to-service propagation.
+func (he HttpError) MarshalJSON() ([]byte, error) {
+	return encodeError(he, false)
+}
+
+// EncodeError encodes the error with its code, status, message, params, meta, stack and cause
+// for service-to-service propagation. Sub-errors are encoded the same way.
+// File, line and request body are not encoded. The result is decoded by HttpError.UnmarshalJSON.
+func EncodeError(he HttpError) ([]byte, error) {
+	return encodeError(he, true)
+}
+
+// encodeError encodes the error into wire format.
+// Internal members are only encoded if full is true.
+func encodeError(he HttpError, full bool) ([]byte, error) {
+	res := errorJSON{
+		Status:     he.Status,
+		Code:       he.Code,
+		Message:    he.Message,
+		Type:       he.Type,
+		Extensions: he.Extensions,
+		Fields:     he.Fields,
+		Reference:  he.Reference,
+		RequestID:  he.RequestID,
+	}
+	if full {
+		res.Params = he.Params
+		res.Meta = he.Meta
+		res.Stack = he.Stack
+		res.Public = he.Public
+		if he.Cause != nil {
+			res.Cause = he.Cause.Error()
+		}
+	}
+
+	for _, sub := range he.Errors {
+		encoded, err := encodeError(sub, full)
+		if err != nil {
+			return nil, err
+		}
+		res.Errors = append(res.Errors, encoded)
+	}
+
+	return json.Marshal(res)
+}
+
+// UnmarshalJSON decodes the error encoded by EncodeError, MarshalJSON or an RFC 9457 problem details document.
+// Unknown members of problem documents are decoded as extensions.
+func (he *HttpError) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var res errorJSON
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+
+	*he = HttpError{
+		Status:     res.Status,
+		Code:       res.Code,
+		Message:    res.Message,
+		Type:       res.Type,
+		Params:     res.Params,
+		Meta:       res.Meta,
+		Extensions: res.Extensions,
+		Fields:     res.Fields,
+		Stack:      res.Stack,
+		Public:     res.Public,
+		Reference:  res.Reference,
+		RequestID:  res.RequestID,
+	}
+
+	if he.Message == "" {
+		he.Message = res.Detail
+	}
+	if he.Message == "" {
+		he.Message = res.Title
+	}
+	if he.Type == "about:blank" {
+		he.Type = ""
+	}
+	if res.Cause != "" {
+		he.Cause = errors.New(res.Cause)
+	}
+	for _, encoded := range res.Errors {
+		var sub HttpError
+		if err := json.Unmarshal(encoded, &sub); err != nil {
+			return err
+		}
+		he.Errors = append(he.Errors, sub)
+	}
+
+	for k, v := range raw {
+		if errorJSONKeys[k] {
+			continue
+		}
+
+		var value any
+		if err := json.Unmarshal(v, &value); err != nil {
+			return err
+		}
+		if he.Extensions == nil {
+			he.Extensions = make(map[string]any)
+		}
+		he.Extensions[k] = value
+	}
+
+	return nil
+}
+
+// DecodeError creates a new HttpError from the error response of another service.
+// The body may be encoded by EncodeError, HttpError.MarshalJSON, ProblemCallback or JSONRenderer;
+// other bodies use the status text as message and are recorded truncated on the UpstreamError cause only.
+// The service is recorded as the cause of the error using UpstreamError.
+// It also captures the file and line number where the error decoded.
+func DecodeError(service string, status int, body []byte) HttpError {
+	he := newHttpError("", status)
+	decodeUpstream(&he, service, status, body)
+	return he
+}
+
+// DecodeResponse creates a new HttpError from the error response of another service like DecodeError.
+// It returns nil if the response status is not an error status (< 400).
+// The response body is read but not closed.
+func DecodeResponse(service string, res *http.Response) error {
+	if res.StatusCode < 400 {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxUpstreamBody))
+	if err != nil {
+		return Wrap(err, fmt.Sprintf("%s: failed to read error response", service), http.StatusBadGateway)
+	}
+
+	he := newHttpError("", res.StatusCode)
+	decodeUpstream(&he, service, res.StatusCode, body)
+	return he
+}
+
+// decodeUpstream decodes the error response body into HttpError.
+func decodeUpstream(he *HttpError, service string, status int, body []byte) {
+	var decoded HttpError
+	var snippet string
+	if err := json.Unmarshal(body, &decoded); err != nil || (decoded.Message == "" && decoded.Code == "") {
+		decoded = HttpError{}
+		snippet = truncateSnippet(strings.TrimSpace(string(body)))
+	}
+	if decoded.Status == 0 {
+		decoded.Status = status
+	}
+	if decoded.Message == "" {
+		decoded.Message = utils.StatusMessage(decoded.Status)
+	}
+
+	he.Status = decoded.Status
+	he.Message = decoded.Message
+	he.Code = decoded.Code
+	he.Params = decoded.Params
+	he.Type = decoded.Type
+	he.Extensions = decoded.Extensions
+	he.Meta = decoded.Meta
+	he.Fields = decoded.Fields
+	he.Errors = decoded.Errors
+	he.Public = decoded.Public
+	he.Cause = UpstreamError{
+		Service:   service,
+		Status:    status,
+		Code:      decoded.Code,
+		Message:   decoded.Message,
+		Reference: decoded.Reference,
+		RequestID: decoded.RequestID,
+		Body:      snippet,
+		Cause:     decoded.Cause,
+	}
+}
+
+// truncateSnippet truncates the s to maxUpstreamSnippet bytes without splitting UTF-8 characters.
+func truncateSnippet(s string) string {
+	if len(s) <= maxUpstreamSnippet {
+		return s
+	}
+
+	s = s[:maxUpstreamSnippet]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s + "..."
+}
@@ -0,0 +1,47 @@
+// Package recovery provides a middleware that converts panics into gohttp.HttpError.
+package recovery
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mekramy/gohttp"
+)
+
+// NewMiddleware creates a new panic recovery middleware for Fiber framework.
+// Recovered panics are converted to gohttp.HttpError with the panicking frame and call stack,
+// so they are logged and rendered by the configured fiber error handler.
+func NewMiddleware(options ...Options) fiber.Handler {
+	// Generate option
+	option := &Option{
+		next:   nil,
+		status: nil,
+	}
+	for _, opt := range options {
+		opt(option)
+	}
+
+	return func(c *fiber.Ctx) (err error) {
+		// Skip
+		if option.next != nil && option.next(c) {
+			return c.Next()
+		}
+
+		// Recover panic
+		defer func() {
+			if r := recover(); r != nil {
+				err = gohttp.NewPanicError(r, resolveStatus(option, r))
+			}
+		}()
+
+		return c.Next()
+	}
+}
+
+// resolveStatus returns the status code of panic value using status hooks.
+func resolveStatus(option *Option, value any) int {
+	for _, hook := range option.status {
+		if status := hook(value); status > 0 {
+			return status
+		}
+	}
+	return fiber.StatusInternalServerError
+}
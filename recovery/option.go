@@ -0,0 +1,30 @@
+package recovery
+
+import "github.com/gofiber/fiber/v2"
+
+// Options defines a function type for configuring Recovery Option.
+type Options func(*Option)
+
+// Option holds the configuration options for Recovery middleware.
+type Option struct {
+	next   func(*fiber.Ctx) bool // Function to skip recovery for certain requests
+	status []func(any) int       // Hooks to resolve status code of panic value
+}
+
+// WithNext sets a custom function to skip recovery for certain requests.
+func WithNext(handler func(*fiber.Ctx) bool) Options {
+	return func(c *Option) {
+		c.next = handler
+	}
+}
+
+// WithStatus adds a hook to resolve the status code of panic value.
+// Hook must return 0 to leave the panic value for next hooks.
+// Panic values not resolved by any hook produce 500 status code.
+func WithStatus(hook func(value any) int) Options {
+	return func(c *Option) {
+		if hook != nil {
+			c.status = append(c.status, hook)
+		}
+	}
+}
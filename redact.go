@@ -0,0 +1,215 @@
+package gohttp
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// RedactOptions defines a function type for configuring RedactOption.
+type RedactOptions func(*RedactOption)
+
+// RedactOption holds the redaction policy applied to captured request data.
+type RedactOption struct {
+	fields    []string         // Case-insensitive field name patterns.
+	values    []*regexp.Regexp // Value patterns to mask.
+	mask      string           // Replacement of redacted values.
+	maxLength int              // Maximum length of captured values, 0 means unlimited.
+	maxFields int              // Maximum number of captured fields, 0 means unlimited.
+}
+
+// DefaultRedactFields is the default list of sensitive field name patterns.
+var DefaultRedactFields = []string{
+	"password", "passwd", "secret", "token", "api_key", "apikey",
+	"authorization", "cookie", "card", "cvv",
+}
+
+// DefaultRedactValues is the default list of sensitive value patterns.
+// It masks credit card numbers and JSON web tokens.
+var DefaultRedactValues = []*regexp.Regexp{
+	regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+	regexp.MustCompile(`\beyJ[\w-]+\.[\w-]+\.[\w-]+`),
+}
+
+// WithRedactFields adds field name patterns to redact.
+// Field is redacted if its name contains any of the patterns (case-insensitive).
+func WithRedactFields(patterns ...string) RedactOptions {
+	return func(o *RedactOption) {
+		for _, p := range patterns {
+			if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+				o.fields = append(o.fields, p)
+			}
+		}
+	}
+}
+
+// WithRedactValues adds value patterns to redact.
+// Matched parts of captured values are replaced with mask.
+func WithRedactValues(patterns ...*regexp.Regexp) RedactOptions {
+	return func(o *RedactOption) {
+		for _, p := range patterns {
+			if p != nil {
+				o.values = append(o.values, p)
+			}
+		}
+	}
+}
+
+// WithoutDefaultRedaction clears the default field name and value patterns.
+func WithoutDefaultRedaction() RedactOptions {
+	return func(o *RedactOption) {
+		o.fields = nil
+		o.values = nil
+	}
+}
+
+// WithRedactMask sets the replacement of redacted values.
+func WithRedactMask(mask string) RedactOptions {
+	return func(o *RedactOption) {
+		o.mask = mask
+	}
+}
+
+// WithMaxValueLength sets the maximum length of captured values.
+// Longer values are truncated. Pass 0 for unlimited length.
+func WithMaxValueLength(length int) RedactOptions {
+	return func(o *RedactOption) {
+		if length >= 0 {
+			o.maxLength = length
+		}
+	}
+}
+
+// WithMaxFields sets the maximum number of captured form fields and files.
+// Extra fields are dropped, query, route parameters and headers are not limited. Pass 0 for unlimited fields.
+func WithMaxFields(count int) RedactOptions {
+	return func(o *RedactOption) {
+		if count >= 0 {
+			o.maxFields = count
+		}
+	}
+}
+
+// redaction is the global redaction policy.
+var redaction atomic.Pointer[RedactOption]
+
+func init() {
+	SetRedaction()
+}
+
+// SetRedaction sets the redaction policy applied to request data captured by NewFormError.
+// Policy created from defaults (DefaultRedactFields, DefaultRedactValues, 1024 characters
+// value length and 100 fields) and the provided options.
+func SetRedaction(options ...RedactOptions) {
+	option := &RedactOption{
+		fields:    slices.Clone(DefaultRedactFields),
+		values:    slices.Clone(DefaultRedactValues),
+		mask:      "[REDACTED]",
+		maxLength: 1024,
+		maxFields: 100,
+	}
+	for _, opt := range options {
+		opt(option)
+	}
+
+	redaction.Store(option)
+}
+
+// redact applies the global redaction policy to the captured body.
+func redact(body map[string]any) map[string]any {
+	if len(body) == 0 {
+		return body
+	}
+
+	option := redaction.Load()
+	keys := slices.Sorted(maps.Keys(body))
+	res := make(map[string]any, len(body))
+	fields, omitted := 0, 0
+	for _, k := range keys {
+		if isFormField(k) {
+			if option.maxFields > 0 && fields >= option.maxFields {
+				omitted++
+				continue
+			}
+			fields++
+		}
+		res[k] = option.value(k, body[k])
+	}
+
+	if omitted > 0 {
+		res["omitted"] = omitted
+	}
+	return res
+}
+
+// isFormField checks if the captured key is a form value or uploaded file.
+func isFormField(k string) bool {
+	return k == "form" || strings.HasPrefix(k, "form.") || strings.HasPrefix(k, "form[") ||
+		strings.HasPrefix(k, "file.")
+}
+
+// value returns the redacted value of the field.
+func (o *RedactOption) value(field string, v any) any {
+	if v == nil {
+		return nil
+	}
+
+	if o.isSensitive(field) {
+		return o.mask
+	}
+
+	switch val := v.(type) {
+	case string:
+		return o.text(val)
+	case []string:
+		res := make([]string, len(val))
+		for i, s := range val {
+			res[i] = o.text(s)
+		}
+		return res
+	case []any:
+		res := make([]any, len(val))
+		for i, item := range val {
+			res[i] = o.value("", item)
+		}
+		return res
+	case map[string]any:
+		res := make(map[string]any, len(val))
+		for k, item := range val {
+			res[k] = o.value(k, item)
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+// isSensitive checks if field name matches any of the field patterns.
+func (o *RedactOption) isSensitive(field string) bool {
+	if field == "" {
+		return false
+	}
+
+	field = strings.ToLower(field)
+	for _, p := range o.fields {
+		if strings.Contains(field, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// text masks the sensitive parts of value and truncates it to max length.
+func (o *RedactOption) text(s string) string {
+	for _, p := range o.values {
+		s = p.ReplaceAllString(s, o.mask)
+	}
+
+	if o.maxLength > 0 && utf8.RuneCountInString(s) > o.maxLength {
+		s = string([]rune(s)[:o.maxLength]) + "..."
+	}
+	return s
+}
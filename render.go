@@ -0,0 +1,241 @@
+package gohttp
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// RenderOptions defines a function type for configuring RenderOption.
+type RenderOptions func(*RenderOption)
+
+// RenderOption holds the renderers used by negotiated error responses.
+type RenderOption struct {
+	json      ErrorCallback              // Renderer for application/json.
+	problem   ErrorCallback              // Renderer for application/problem+json.
+	xml       ErrorCallback              // Renderer for application/xml.
+	html      ErrorCallback              // Renderer for text/html.
+	text      ErrorCallback              // Renderer for text/plain.
+	templates map[int]*template.Template // HTML templates per status code.
+	template  *template.Template         // Fallback HTML template.
+}
+
+// WithJSONRenderer sets the renderer used for JSON responses.
+func WithJSONRenderer(renderer ErrorCallback) RenderOptions {
+	return func(o *RenderOption) {
+		if renderer != nil {
+			o.json = renderer
+		}
+	}
+}
+
+// WithProblemRenderer sets the renderer used for application/problem+json responses.
+// ProblemCallback is used by default.
+func WithProblemRenderer(renderer ErrorCallback) RenderOptions {
+	return func(o *RenderOption) {
+		if renderer != nil {
+			o.problem = renderer
+		}
+	}
+}
+
+// WithXMLRenderer sets the renderer used for XML responses.
+func WithXMLRenderer(renderer ErrorCallback) RenderOptions {
+	return func(o *RenderOption) {
+		if renderer != nil {
+			o.xml = renderer
+		}
+	}
+}
+
+// WithHTMLRenderer sets the renderer used for HTML responses.
+// Custom renderer ignores the configured templates.
+func WithHTMLRenderer(renderer ErrorCallback) RenderOptions {
+	return func(o *RenderOption) {
+		if renderer != nil {
+			o.html = renderer
+		}
+	}
+}
+
+// WithTextRenderer sets the renderer used for plain text responses.
+func WithTextRenderer(renderer ErrorCallback) RenderOptions {
+	return func(o *RenderOption) {
+		if renderer != nil {
+			o.text = renderer
+		}
+	}
+}
+
+// WithTemplate sets the HTML template used for the given status code.
+// Template executed with ErrorView data.
+func WithTemplate(status int, tmpl *template.Template) RenderOptions {
+	return func(o *RenderOption) {
+		if tmpl != nil {
+			o.templates[status] = tmpl
+		}
+	}
+}
+
+// WithDefaultTemplate sets the HTML template used for status codes without dedicated template.
+// Template executed with ErrorView data.
+func WithDefaultTemplate(tmpl *template.Template) RenderOptions {
+	return func(o *RenderOption) {
+		if tmpl != nil {
+			o.template = tmpl
+		}
+	}
+}
+
+// ErrorView is the data structure used by default renderers and HTML templates.
+type ErrorView struct {
+	XMLName   xml.Name     `json:"-" xml:"error"`
+	Status    int          `json:"status" xml:"status"`
+	Title     string       `json:"title" xml:"title"`
+	Code      string       `json:"code,omitempty" xml:"code,omitempty"`
+	Message   string       `json:"message" xml:"message"`
+	Reference string       `json:"reference,omitempty" xml:"reference,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty" xml:"errors>error,omitempty"`
+	Stack     []Frame      `json:"stack,omitempty" xml:"stack>frame,omitempty"`
+	Details   []ErrorView  `json:"details,omitempty" xml:"details>error,omitempty"`
+}
+
+// NewErrorView creates the view data of the provided HttpError.
+// Sub-errors of joined errors are rendered as details.
+func NewErrorView(err HttpError) ErrorView {
+	view := ErrorView{
+		Status:    err.Status,
+		Title:     utils.StatusMessage(err.Status),
+		Message:   err.Message,
+		Code:      err.Code,
+		Reference: err.Reference,
+		Errors:    err.Fields,
+		Stack:     err.Stack,
+	}
+	for _, sub := range err.Errors {
+		view.Details = append(view.Details, NewErrorView(sub))
+	}
+	return view
+}
+
+// defaultTemplate is the fallback HTML error page.
+var defaultTemplate = template.Must(template.New("error").Parse(
+	`<!DOCTYPE html><html><head><meta charset="utf-8"><title>{{.Status}} {{.Title}}</title></head>` +
+		`<body><h1>{{.Status}} {{.Title}}</h1><p>{{.Message}}</p>` +
+		`{{if .Code}}<p><small>Code: {{.Code}}</small></p>{{end}}` +
+		`{{if .Reference}}<p><small>Reference: {{.Reference}}</small></p>{{end}}` +
+		`{{if .Errors}}<ul>{{range .Errors}}<li><strong>{{.Field}}</strong>: {{.Message}}</li>{{end}}</ul>{{end}}` +
+		`{{if .Stack}}<pre>{{range .Stack}}{{.}}{{"\n"}}{{end}}</pre>{{end}}` +
+		`{{if .Details}}<ol>{{range .Details}}<li>{{.Status}} {{.Message}}` +
+		`{{if .Errors}}<ul>{{range .Errors}}<li><strong>{{.Field}}</strong>: {{.Message}}</li>{{end}}</ul>{{end}}` +
+		`</li>{{end}}</ol>{{end}}` +
+		`</body></html>`,
+))
+
+// NegotiateCallback creates an ErrorCallback that selects the response renderer
+// based on the request Accept header. JSON, problem+json, XML, HTML and plain text formats are supported.
+// Plain text is used when the Accept header is missing or nothing matches.
+func NegotiateCallback(options ...RenderOptions) ErrorCallback {
+	// Generate option
+	option := &RenderOption{
+		json:      JSONRenderer,
+		problem:   ProblemCallback,
+		xml:       XMLRenderer,
+		html:      nil,
+		text:      TextRenderer,
+		templates: make(map[int]*template.Template),
+		template:  defaultTemplate,
+	}
+	for _, opt := range options {
+		opt(option)
+	}
+
+	if option.html == nil {
+		option.html = func(ctx *fiber.Ctx, err HttpError) error {
+			tmpl, ok := option.templates[err.Status]
+			if !ok {
+				tmpl = option.template
+			}
+
+			var buf bytes.Buffer
+			if e := tmpl.Execute(&buf, NewErrorView(err)); e != nil {
+				return e
+			}
+
+			ctx.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+			return ctx.Status(err.Status).Send(buf.Bytes())
+		}
+	}
+
+	return func(ctx *fiber.Ctx, err HttpError) error {
+		ctx.Vary(fiber.HeaderAccept)
+		switch ctx.Accepts(
+			fiber.MIMETextPlain,
+			fiber.MIMEApplicationJSON,
+			MIMEApplicationProblemJSON,
+			fiber.MIMEApplicationXML,
+			fiber.MIMETextXML,
+			fiber.MIMETextHTML,
+		) {
+		case fiber.MIMEApplicationJSON:
+			return option.json(ctx, err)
+		case MIMEApplicationProblemJSON:
+			return option.problem(ctx, err)
+		case fiber.MIMEApplicationXML, fiber.MIMETextXML:
+			return option.xml(ctx, err)
+		case fiber.MIMETextHTML:
+			return option.html(ctx, err)
+		default:
+			return option.text(ctx, err)
+		}
+	}
+}
+
+// JSONRenderer is an ErrorCallback that renders the error as JSON object.
+func JSONRenderer(ctx *fiber.Ctx, err HttpError) error {
+	return ctx.Status(err.Status).JSON(NewErrorView(err))
+}
+
+// XMLRenderer is an ErrorCallback that renders the error as XML document.
+func XMLRenderer(ctx *fiber.Ctx, err HttpError) error {
+	encoded, e := xml.Marshal(NewErrorView(err))
+	if e != nil {
+		return e
+	}
+
+	ctx.Set(fiber.HeaderContentType, fiber.MIMEApplicationXMLCharsetUTF8)
+	return ctx.Status(err.Status).Send(encoded)
+}
+
+// TextRenderer is an ErrorCallback that renders the error message as plain text.
+// Code, reference, field failures, stack frames and sub-errors are rendered one per line after the message.
+func TextRenderer(ctx *fiber.Ctx, err HttpError) error {
+	var text strings.Builder
+	text.WriteString(err.Message)
+	if err.Code != "" {
+		text.WriteString("\ncode: " + err.Code)
+	}
+	if err.Reference != "" {
+		text.WriteString("\nreference: " + err.Reference)
+	}
+	for _, field := range err.Fields {
+		text.WriteString("\n" + field.Field + ": " + field.Message)
+	}
+	for _, frame := range err.Stack {
+		text.WriteString("\n\t" + frame.String())
+	}
+	for _, sub := range err.Errors {
+		text.WriteString(fmt.Sprintf("\n- %d %s", sub.Status, sub.Message))
+		for _, field := range sub.Fields {
+			text.WriteString("\n  " + field.Field + ": " + field.Message)
+		}
+	}
+
+	ctx.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	return ctx.Status(err.Status).SendString(text.String())
+}
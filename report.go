@@ -0,0 +1,186 @@
+package gohttp
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/mekramy/gohttp/session"
+)
+
+// ErrorReport represents an error with its request metadata forwarded to reporters.
+type ErrorReport struct {
+	Error     HttpError // Handled error.
+	Time      time.Time // Time of the error.
+	Method    string    // Request method.
+	Path      string    // Request path.
+	Route     string    // Matched route pattern.
+	IP        string    // Client IP.
+	UserAgent string    // Client user agent.
+	SessionID string    // Session ID if session middleware is registered.
+}
+
+// NewErrorReport creates a new error report of the request.
+func NewErrorReport(ctx *fiber.Ctx, err HttpError) ErrorReport {
+	report := ErrorReport{
+		Error:     err,
+		Time:      time.Now(),
+		Method:    utils.CopyString(ctx.Method()),
+		Path:      utils.CopyString(ctx.Path()),
+		Route:     utils.CopyString(ctx.Route().Path),
+		IP:        utils.CopyString(ctx.IP()),
+		UserAgent: utils.CopyString(ctx.Get(fiber.HeaderUserAgent)),
+	}
+
+	if s := session.Parse(ctx); s != nil {
+		report.SessionID = s.Id()
+	}
+
+	return report
+}
+
+// MarshalJSON encodes the report as a flat JSON object. Sub-errors of joined errors are encoded as "details".
+func (r ErrorReport) MarshalJSON() ([]byte, error) {
+	res := map[string]any{
+		"time":   r.Time.Format(time.RFC3339Nano),
+		"method": r.Method,
+		"path":   r.Path,
+		"route":  r.Route,
+		"ip":     r.IP,
+		"agent":  r.UserAgent,
+	}
+	reportError(res, r.Error)
+
+	if r.SessionID != "" {
+		res["session"] = r.SessionID
+	}
+	if r.Error.RequestID != "" {
+		res["request_id"] = r.Error.RequestID
+	}
+	if len(r.Error.Body) > 0 {
+		res["body"] = r.Error.Body
+	}
+	if len(r.Error.Errors) > 0 {
+		details := make([]map[string]any, len(r.Error.Errors))
+		for i, sub := range r.Error.Errors {
+			details[i] = make(map[string]any)
+			reportError(details[i], sub)
+		}
+		res["details"] = details
+	}
+
+	return json.Marshal(res)
+}
+
+// reportError adds the error members of report into res.
+func reportError(res map[string]any, err HttpError) {
+	res["status"] = err.Status
+	res["message"] = err.Message
+	if err.File != "" {
+		res["file"] = relativePath(err.File)
+		res["line"] = err.Line
+	}
+
+	if err.Code != "" {
+		res["code"] = err.Code
+	}
+	if err.Reference != "" {
+		res["reference"] = err.Reference
+	}
+	if causes := causeChain(err.Cause); len(causes) > 0 {
+		res["causes"] = causes
+	}
+	if len(err.Stack) > 0 {
+		res["stack"] = stackStrings(err.Stack)
+	}
+	if len(err.Meta) > 0 {
+		res["meta"] = err.Meta
+	}
+	if len(err.Fields) > 0 {
+		res["fields"] = err.Fields
+	}
+}
+
+// ErrorReporter is an interface that forwards errors to external sinks such as issue trackers.
+type ErrorReporter interface {
+	// Report forwards the error report.
+	Report(report ErrorReport) error
+}
+
+// ReportQueue runs error reporters asynchronously using a bounded queue and worker pool.
+type ReportQueue struct {
+	reporters []ErrorReporter
+	queue     chan ErrorReport
+	onError   func(error)
+	wg        sync.WaitGroup
+	mutex     sync.RWMutex
+	closed    bool
+}
+
+// NewReportQueue creates a new report queue with the provided queue size and number of workers.
+// Reports pushed to a full queue are dropped.
+func NewReportQueue(size, workers uint, reporters ...ErrorReporter) *ReportQueue {
+	if workers == 0 {
+		workers = 1
+	}
+
+	q := &ReportQueue{
+		reporters: reporters,
+		queue:     make(chan ErrorReport, size),
+	}
+
+	q.wg.Add(int(workers))
+	for range workers {
+		go q.work()
+	}
+	return q
+}
+
+// OnError sets the handler of reporter failures.
+func (q *ReportQueue) OnError(handler func(err error)) *ReportQueue {
+	q.onError = handler
+	return q
+}
+
+// Push adds the report to queue.
+// It returns false if the queue is full or closed.
+func (q *ReportQueue) Push(report ErrorReport) bool {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	if q.closed {
+		return false
+	}
+
+	select {
+	case q.queue <- report:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close stops accepting reports and waits for queued reports to be processed.
+func (q *ReportQueue) Close() {
+	q.mutex.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.queue)
+	}
+	q.mutex.Unlock()
+	q.wg.Wait()
+}
+
+// work processes queued reports.
+func (q *ReportQueue) work() {
+	defer q.wg.Done()
+	for report := range q.queue {
+		for _, reporter := range q.reporters {
+			if err := reporter.Report(report); err != nil && q.onError != nil {
+				q.onError(err)
+			}
+		}
+	}
+}
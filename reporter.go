@@ -0,0 +1,115 @@
+package gohttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileReporter is an ErrorReporter that appends reports to a file as newline delimited JSON.
+type FileReporter struct {
+	file  *os.File
+	mutex sync.Mutex
+}
+
+// NewFileReporter creates a new NDJSON file reporter.
+// File is created if not exists and reports are appended to it.
+func NewFileReporter(path string) (*FileReporter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileReporter{file: f}, nil
+}
+
+// Report appends the report to the file as a JSON line.
+func (r *FileReporter) Report(report ErrorReport) error {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, err = r.file.Write(append(encoded, '\n'))
+	return err
+}
+
+// Close closes the underlying file.
+func (r *FileReporter) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.file.Close()
+}
+
+// maxDrainBody is the maximum size of webhook response body drained for connection reuse.
+const maxDrainBody = 64 * 1024
+
+// WebhookReporter is an ErrorReporter that posts reports as JSON to a webhook URL.
+type WebhookReporter struct {
+	url     string
+	client  *http.Client
+	headers map[string]string
+}
+
+// NewWebhookReporter creates a new webhook reporter posting to the url.
+// Default http client has 10 seconds timeout.
+func NewWebhookReporter(url string) *WebhookReporter {
+	return &WebhookReporter{
+		url:     url,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		headers: make(map[string]string),
+	}
+}
+
+// WithClient sets the http client used to post reports.
+func (r *WebhookReporter) WithClient(client *http.Client) *WebhookReporter {
+	if client != nil {
+		r.client = client
+	}
+	return r
+}
+
+// WithHeader sets a request header sent with reports, e.g. authorization token.
+func (r *WebhookReporter) WithHeader(key, value string) *WebhookReporter {
+	r.headers[key] = value
+	return r
+}
+
+// Report posts the report as JSON to the webhook URL.
+// Non 2xx response status codes are returned as error.
+func (r *WebhookReporter) Report(report ErrorReport) error {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.url, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range r.headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	defer io.Copy(io.Discard, io.LimitReader(res.Body, maxDrainBody))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %d status code", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package gohttp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testReport() ErrorReport {
+	return ErrorReport{
+		Error: HttpError{
+			Status:    http.StatusInternalServerError,
+			Message:   "database is down",
+			Code:      "db_down",
+			Reference: "ref-1",
+			RequestID: "req-1",
+		},
+		Time:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Method: http.MethodGet,
+		Path:   "/users/1",
+		Route:  "/users/:id",
+		IP:     "127.0.0.1",
+	}
+}
+
+func TestWebhookReporterPayload(t *testing.T) {
+	var header http.Header
+	var payload map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid payload %q: %v", body, err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	reporter := NewWebhookReporter(server.URL).WithHeader("Authorization", "Bearer token")
+	if err := reporter.Report(testReport()); err != nil {
+		t.Fatal(err)
+	}
+
+	if ct := header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	if auth := header.Get("Authorization"); auth != "Bearer token" {
+		t.Errorf("unexpected authorization header %q", auth)
+	}
+
+	expected := map[string]any{
+		"status":     float64(http.StatusInternalServerError),
+		"message":    "database is down",
+		"code":       "db_down",
+		"reference":  "ref-1",
+		"request_id": "req-1",
+		"method":     http.MethodGet,
+		"path":       "/users/1",
+		"route":      "/users/:id",
+		"time":       "2024-01-02T03:04:05Z",
+	}
+	for k, v := range expected {
+		if payload[k] != v {
+			t.Errorf("unexpected %s: %v, expected %v", k, payload[k], v)
+		}
+	}
+}
+
+func TestWebhookReporterStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		io.WriteString(w, strings.Repeat("x", 2*maxDrainBody))
+	}))
+	defer server.Close()
+
+	err := NewWebhookReporter(server.URL).Report(testReport())
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("expected 502 status error, got %v", err)
+	}
+}
+
+func TestFileReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.ndjson")
+	reporter, err := NewFileReporter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if err := reporter.Report(testReport()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := reporter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		if line["message"] != "database is down" {
+			t.Errorf("unexpected message %v", line["message"])
+		}
+		lines++
+	}
+	if lines != 2 {
+		t.Fatalf("expected 2 lines, got %d", lines)
+	}
+}
+
+func TestReportQueueClose(t *testing.T) {
+	q := NewReportQueue(1, 1)
+	q.Close()
+	q.Close()
+
+	if q.Push(testReport()) {
+		t.Fatal("expected push to closed queue to fail")
+	}
+}
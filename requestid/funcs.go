@@ -0,0 +1,27 @@
+package requestid
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// Parse extracts the request ID from the fiber.Ctx context.
+// It returns empty string if request ID middleware is not registered.
+func Parse(c *fiber.Ctx) string {
+	id, _ := c.Locals("REQUEST_ID").(string)
+	return id
+}
+
+// isValid checks if the incoming request ID is safe to reuse.
+// Only printable ASCII characters without spaces and up to 128 characters are accepted.
+func isValid(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+// Package requestid provides a middleware that assigns a correlation ID to each request.
+package requestid
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// NewMiddleware creates a new request ID middleware for Fiber framework.
+// It reads the request ID from the X-Request-ID header or generates a new one,
+// stores it in the context and echoes it in the response header.
+func NewMiddleware(options ...Options) fiber.Handler {
+	// Generate option
+	option := &Option{
+		header:    fiber.HeaderXRequestID,
+		generator: UUIDGenerator,
+		next:      nil,
+	}
+	for _, opt := range options {
+		opt(option)
+	}
+
+	return func(c *fiber.Ctx) error {
+		// Skip
+		if option.next != nil && option.next(c) {
+			return c.Next()
+		}
+
+		// Read or generate id
+		id := c.Get(option.header)
+		if isValid(id) {
+			id = utils.CopyString(id)
+		} else {
+			id = option.generator()
+		}
+
+		// Store to context and response
+		c.Locals("REQUEST_ID", id)
+		c.Append("Access-Control-Expose-Headers", option.header)
+		c.Set(option.header, id)
+
+		return c.Next()
+	}
+}
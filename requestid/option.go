@@ -0,0 +1,42 @@
+package requestid
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Options defines a function type for configuring Request ID Option.
+type Options func(*Option)
+
+// Option holds the configuration options for Request ID middleware.
+type Option struct {
+	header    string                // Request and response header name
+	generator IdGenerator           // Function used to generate request IDs
+	next      func(*fiber.Ctx) bool // Function to skip middleware for certain requests
+}
+
+// WithHeader sets the request and response header name.
+func WithHeader(name string) Options {
+	return func(c *Option) {
+		if name = strings.TrimSpace(name); name != "" {
+			c.header = name
+		}
+	}
+}
+
+// WithGenerator sets the request ID generator.
+func WithGenerator(generator IdGenerator) Options {
+	return func(c *Option) {
+		if generator != nil {
+			c.generator = generator
+		}
+	}
+}
+
+// WithNext sets a custom function to skip middleware for certain requests.
+func WithNext(handler func(*fiber.Ctx) bool) Options {
+	return func(c *Option) {
+		c.next = handler
+	}
+}
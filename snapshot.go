@@ -0,0 +1,152 @@
+package gohttp
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// SnapshotOptions defines a function type for configuring SnapshotOption.
+type SnapshotOptions func(*SnapshotOption)
+
+// SnapshotOption holds the request snapshot configuration of NewFormError.
+type SnapshotOption struct {
+	query   bool     // Capture query parameters.
+	params  bool     // Capture route parameters.
+	headers []string // Allowlist of captured headers.
+	budget  int      // Total size budget of captured data in bytes, 0 means unlimited.
+}
+
+// WithoutSnapshotQuery disables capturing query parameters.
+func WithoutSnapshotQuery() SnapshotOptions {
+	return func(o *SnapshotOption) {
+		o.query = false
+	}
+}
+
+// WithoutSnapshotParams disables capturing route parameters.
+func WithoutSnapshotParams() SnapshotOptions {
+	return func(o *SnapshotOption) {
+		o.params = false
+	}
+}
+
+// WithSnapshotHeaders sets the allowlist of captured request headers.
+func WithSnapshotHeaders(names ...string) SnapshotOptions {
+	return func(o *SnapshotOption) {
+		o.headers = o.headers[:0]
+		for _, name := range names {
+			if name = strings.TrimSpace(name); name != "" {
+				o.headers = append(o.headers, name)
+			}
+		}
+	}
+}
+
+// WithSnapshotBudget sets the total size budget of captured data in bytes.
+// Fields exceeding the budget are dropped. Pass 0 for unlimited size.
+func WithSnapshotBudget(size int) SnapshotOptions {
+	return func(o *SnapshotOption) {
+		if size >= 0 {
+			o.budget = size
+		}
+	}
+}
+
+// snapshot is the global request snapshot configuration.
+var snapshot atomic.Pointer[SnapshotOption]
+
+func init() {
+	SetSnapshot()
+}
+
+// SetSnapshot sets the request snapshot configuration of NewFormError.
+// Configuration created from defaults (query and route parameters, Content-Type and
+// User-Agent headers and 8KB budget) and the provided options.
+func SetSnapshot(options ...SnapshotOptions) {
+	option := &SnapshotOption{
+		query:   true,
+		params:  true,
+		headers: []string{fiber.HeaderContentType, fiber.HeaderUserAgent},
+		budget:  8 * 1024,
+	}
+	for _, opt := range options {
+		opt(option)
+	}
+
+	snapshot.Store(option)
+}
+
+// requestSnapshot adds the query, route parameters and allowed headers of request to body.
+func requestSnapshot(ctx *fiber.Ctx, body map[string]any) {
+	option := snapshot.Load()
+	if option.query {
+		for k, v := range ctx.Queries() {
+			body["query."+utils.CopyString(k)] = utils.CopyString(v)
+		}
+	}
+
+	if option.params {
+		for k, v := range ctx.AllParams() {
+			body["param."+utils.CopyString(k)] = utils.CopyString(v)
+		}
+	}
+
+	for _, name := range option.headers {
+		if v := ctx.Get(name); v != "" {
+			body["header."+strings.ToLower(name)] = utils.CopyString(v)
+		}
+	}
+}
+
+// flattenValue adds the value to dst, nested objects and arrays are flattened
+// using dotted keys and indexes, e.g. "items[2].price".
+func flattenValue(dst map[string]any, key string, v any) {
+	switch val := v.(type) {
+	case map[string]any:
+		if len(val) == 0 {
+			dst[key] = val
+		}
+		for k, item := range val {
+			flattenValue(dst, key+"."+k, item)
+		}
+	case []any:
+		if len(val) == 0 {
+			dst[key] = val
+		}
+		for i, item := range val {
+			flattenValue(dst, fmt.Sprintf("%s[%d]", key, i), item)
+		}
+	default:
+		dst[key] = v
+	}
+}
+
+// truncateBody drops the fields of body that do not fit in the snapshot size budget.
+func truncateBody(body map[string]any) map[string]any {
+	option := snapshot.Load()
+	if option.budget <= 0 || len(body) == 0 {
+		return body
+	}
+
+	size, dropped := 0, 0
+	for _, k := range slices.Sorted(maps.Keys(body)) {
+		n := len(k) + len(fmt.Sprint(body[k]))
+		if size+n > option.budget {
+			delete(body, k)
+			dropped++
+			continue
+		}
+		size += n
+	}
+
+	if dropped > 0 {
+		body["truncated"] = dropped
+	}
+	return body
+}
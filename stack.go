@@ -0,0 +1,121 @@
+package gohttp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// captureStack indicates whether constructors capture the full call stack.
+var captureStack atomic.Bool
+
+// CaptureStack enables or disables capturing the full call stack when
+// NewError, NewFormError, NewValidationError or Wrap is called.
+// Stack capturing is disabled by default.
+func CaptureStack(enabled bool) {
+	captureStack.Store(enabled)
+}
+
+// Frame represents a single frame of the captured call stack.
+type Frame struct {
+	Function string `json:"function" xml:"function,attr"` // Fully qualified function name.
+	File     string `json:"file" xml:"file,attr"`         // File name of the frame.
+	Line     int    `json:"line" xml:"line,attr"`         // Line number of the frame.
+}
+
+// String returns the frame in "file:line function" format.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s:%d %s", f.File, f.Line, f.Function)
+}
+
+// callerStack returns the call stack of error caller func if stack capturing is enabled.
+func callerStack() []Frame {
+	if !captureStack.Load() {
+		return nil
+	}
+
+	return stackFrom(3)
+}
+
+// stackFrom returns the call stack skipping the given number of frames.
+func stackFrom(skip int) []Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+1, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	res := make([]Frame, 0, n)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "runtime.goexit" {
+			res = append(res, Frame{
+				Function: frame.Function,
+				File:     frame.File,
+				Line:     frame.Line,
+			})
+		}
+
+		if !more {
+			break
+		}
+	}
+	return res
+}
+
+// panicStack returns the call stack of the panicking function.
+// It must be called from deferred function that recovered the panic.
+func panicStack() []Frame {
+	stack := stackFrom(3)
+	for i, frame := range stack {
+		if frame.Function == "runtime.gopanic" {
+			stack = stack[i+1:]
+			break
+		}
+	}
+
+	for len(stack) > 1 && strings.HasPrefix(stack[0].Function, "runtime.") {
+		stack = stack[1:]
+	}
+	return stack
+}
+
+// relativePath returns the path relative to APP_ROOT environment variable.
+func relativePath(path string) string {
+	root := filepath.ToSlash(os.Getenv("APP_ROOT"))
+	path = filepath.ToSlash(path)
+	if root != "" {
+		if p, err := filepath.Rel(root, path); err == nil {
+			return filepath.ToSlash(p)
+		}
+	}
+
+	return path
+}
+
+// relativeStack returns a copy of the stack with file names relative to APP_ROOT environment variable.
+func relativeStack(stack []Frame) []Frame {
+	if len(stack) == 0 {
+		return nil
+	}
+
+	res := make([]Frame, len(stack))
+	for i, frame := range stack {
+		frame.File = relativePath(frame.File)
+		res[i] = frame
+	}
+	return res
+}
+
+// stackStrings returns the string representation of stack frames.
+func stackStrings(stack []Frame) []string {
+	res := make([]string, len(stack))
+	for i, frame := range stack {
+		res[i] = frame.String()
+	}
+	return res
+}
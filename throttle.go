@@ -0,0 +1,80 @@
+package gohttp
+
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mekramy/gocache"
+	"github.com/mekramy/gocast"
+)
+
+// CacheAdder is an optional interface of gocache.Cache implementations that stores
+// the value only if the key doesn't exist in one atomic step (e.g. redis SETNX).
+// It returns true if the value is stored.
+// Shared caches should implement it to open throttle windows atomically across instances.
+type CacheAdder interface {
+	Add(key string, value any, ttl *time.Duration) (bool, error)
+}
+
+// throttle deduplicates repeated error logs within a time window.
+type throttle struct {
+	window time.Duration
+	cache  gocache.Cache
+	mutex  sync.Mutex
+}
+
+// allow records an occurrence of the key and reports whether it must be logged.
+// Windows are aligned to the window duration, so every window has its own counter key
+// shared across instances. The first occurrence of a window is allowed and the summary
+// func called with number of suppressed duplicates when the window closes.
+// Windows are opened atomically within the instance and across instances if cache implements CacheAdder.
+func (t *throttle) allow(key string, summary func(suppressed int64)) bool {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+
+	now := time.Now()
+	id := now.UnixNano() / int64(t.window)
+	counter := "err-throttle-" + strconv.FormatUint(h.Sum64(), 36) + "-" + strconv.FormatInt(id, 36)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	// Suppress duplicates in open window
+	if ok, err := t.cache.Increment(counter, 1); err != nil {
+		return true
+	} else if ok {
+		return false
+	}
+
+	// Open new window
+	ttl := 2 * t.window
+	if adder, ok := t.cache.(CacheAdder); ok {
+		added, err := adder.Add(counter, 0, &ttl)
+		if err != nil {
+			return true
+		} else if !added {
+			// Opened by another instance
+			if ok, err := t.cache.Increment(counter, 1); err == nil && ok {
+				return false
+			}
+			return true
+		}
+	} else if err := t.cache.Put(counter, 0, &ttl); err != nil {
+		return true
+	}
+
+	closes := time.Unix(0, (id+1)*int64(t.window))
+	time.AfterFunc(closes.Sub(now), func() {
+		suppressed, err := t.cache.Pull(counter)
+		if err != nil {
+			return
+		}
+
+		if n := gocast.NewCaster(suppressed).Int64Safe(0); n > 0 {
+			summary(n)
+		}
+	})
+	return true
+}
@@ -0,0 +1,235 @@
+package gohttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mekramy/gocast"
+	"github.com/mekramy/gohttp/session"
+	"gopkg.in/yaml.v3"
+)
+
+// Translator is an interface that translates error messages.
+type Translator interface {
+	// Translate returns the translated message of the key for the locale using params.
+	// Empty locale must be treated as translator default language.
+	// It returns false if no translation found.
+	Translate(locale, key string, params map[string]any) (string, bool)
+}
+
+// LocaleResolver is a function type that resolves the request locale.
+// It returns empty string if locale cannot be resolved.
+type LocaleResolver func(ctx *fiber.Ctx) string
+
+// AcceptLanguageLocale resolves the locale from Accept-Language header among the supported locales.
+func AcceptLanguageLocale(supported ...string) LocaleResolver {
+	return func(ctx *fiber.Ctx) string {
+		if ctx.Get(fiber.HeaderAcceptLanguage) == "" || len(supported) == 0 {
+			return ""
+		}
+		return ctx.AcceptsLanguages(supported...)
+	}
+}
+
+// QueryLocale resolves the locale from the query parameter.
+func QueryLocale(name string) LocaleResolver {
+	return func(ctx *fiber.Ctx) string {
+		return strings.TrimSpace(ctx.Query(name))
+	}
+}
+
+// SessionLocale resolves the locale from the session value.
+// Session middleware must be registered before the resolver is called.
+func SessionLocale(key string) LocaleResolver {
+	return func(ctx *fiber.Ctx) string {
+		if s := session.Parse(ctx); s != nil {
+			return strings.TrimSpace(s.Cast(key).StringSafe(""))
+		}
+		return ""
+	}
+}
+
+// Catalog is a Translator backed by in-memory message catalogs.
+// Message values are either a string or a plural form object with "zero", "one"
+// and "other" keys selected by "count" param. Nested objects are flattened with dot.
+type Catalog struct {
+	fallback string
+	messages map[string]map[string]any
+	mutex    sync.RWMutex
+}
+
+// NewCatalog creates a new empty Catalog with the fallback language.
+func NewCatalog(fallback string) *Catalog {
+	return &Catalog{
+		fallback: normalizeLocale(fallback),
+		messages: make(map[string]map[string]any),
+	}
+}
+
+// Add adds messages of the locale to catalog.
+func (c *Catalog) Add(locale string, messages map[string]any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	locale = normalizeLocale(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]any)
+	}
+	flattenMessages(c.messages[locale], "", messages)
+}
+
+// LoadJSON adds the JSON encoded messages of the locale to catalog.
+func (c *Catalog) LoadJSON(locale string, data []byte) error {
+	messages := make(map[string]any)
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return err
+	}
+
+	c.Add(locale, messages)
+	return nil
+}
+
+// LoadYAML adds the YAML encoded messages of the locale to catalog.
+func (c *Catalog) LoadYAML(locale string, data []byte) error {
+	messages := make(map[string]any)
+	if err := yaml.Unmarshal(data, &messages); err != nil {
+		return err
+	}
+
+	c.Add(locale, messages)
+	return nil
+}
+
+// LoadFile adds the messages of JSON or YAML file to catalog.
+// Locale resolved from file name, e.g. "fa.json" or "en-US.yaml".
+func (c *Catalog) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(path)
+	locale := strings.TrimSuffix(filepath.Base(path), ext)
+	switch strings.ToLower(ext) {
+	case ".json":
+		return c.LoadJSON(locale, data)
+	case ".yaml", ".yml":
+		return c.LoadYAML(locale, data)
+	default:
+		return fmt.Errorf("unsupported catalog file %s", path)
+	}
+}
+
+// LoadDir adds the messages of all JSON and YAML files of the directory to catalog.
+func (c *Catalog) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json", ".yaml", ".yml":
+			if err := c.LoadFile(filepath.Join(dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Translate returns the message of key for locale formatted with params.
+// It tries the exact locale, its base language and the fallback locale respectively.
+// Plural forms are selected by the "count" param.
+func (c *Catalog) Translate(locale, key string, params map[string]any) (string, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	locale = normalizeLocale(locale)
+	candidates := []string{locale}
+	if base, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, base)
+	}
+	candidates = append(candidates, c.fallback)
+
+	for _, l := range candidates {
+		if v, ok := c.messages[l][key]; ok {
+			if msg, ok := pluralMessage(v, params); ok {
+				return formatMessage(msg, params), true
+			}
+		}
+	}
+	return "", false
+}
+
+// normalizeLocale returns lower case locale with dash separator.
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// isPluralForms checks if message object is plural forms definition.
+func isPluralForms(v map[string]any) bool {
+	if _, ok := v["other"]; !ok {
+		return false
+	}
+
+	for k := range v {
+		switch k {
+		case "zero", "one", "two", "few", "many", "other":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// flattenMessages flattens nested message objects into dst using dot separated keys.
+func flattenMessages(dst map[string]any, prefix string, src map[string]any) {
+	for k, v := range src {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+
+		if m, ok := v.(map[string]any); ok && !isPluralForms(m) {
+			flattenMessages(dst, k, m)
+		} else {
+			dst[k] = v
+		}
+	}
+}
+
+// pluralMessage resolves the message of catalog value using "count" param.
+func pluralMessage(v any, params map[string]any) (string, bool) {
+	switch msg := v.(type) {
+	case string:
+		return msg, true
+	case map[string]any:
+		count := gocast.NewCaster(params["count"]).Int64Safe(0)
+
+		form := "other"
+		if _, ok := msg["zero"]; ok && count == 0 {
+			form = "zero"
+		} else if count == 1 {
+			form = "one"
+		} else if count == 2 {
+			form = "two"
+		}
+
+		if s, ok := msg[form].(string); ok {
+			return s, true
+		}
+		s, ok := msg["other"].(string)
+		return s, ok
+	default:
+		return "", false
+	}
+}
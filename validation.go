@@ -0,0 +1,53 @@
+package gohttp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FieldError represents a validation failure of a single request field.
+type FieldError struct {
+	Field   string `json:"field" xml:"field,attr"`  // Field path, e.g. "items[2].price".
+	Message string `json:"message" xml:",chardata"` // Validation failure message.
+}
+
+// FieldPath builds a nested field path from the provided parts.
+// String parts are joined with dot and integer parts are rendered as index.
+// For example FieldPath("items", 2, "price") returns "items[2].price".
+func FieldPath(parts ...any) string {
+	var path strings.Builder
+	for _, part := range parts {
+		switch p := part.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			fmt.Fprintf(&path, "[%d]", p)
+		default:
+			s := strings.TrimSpace(fmt.Sprint(p))
+			if s == "" {
+				continue
+			}
+			if path.Len() > 0 {
+				path.WriteString(".")
+			}
+			path.WriteString(s)
+		}
+	}
+	return path.String()
+}
+
+// NewValidationError creates a new HttpError with 422 status code and the provided field failures.
+// It captures the file and line number where the error occurred, the call stack if CaptureStack is enabled
+// and includes request body data if available.
+func NewValidationError(ctx *fiber.Ctx, fields ...FieldError) error {
+	file, line, _ := realCaller()
+	return HttpError{
+		Line:    line,
+		File:    file,
+		Body:    formBody(ctx),
+		Status:  fiber.StatusUnprocessableEntity,
+		Message: "Validation failed",
+		Fields:  fields,
+		Stack:   callerStack(),
+	}
+}